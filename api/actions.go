@@ -25,11 +25,9 @@ THE SOFTWARE.
 package api
 
 import (
-	"strings"
-
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
-	"github.com/J-Siu/go-gitapi/v3/vendor"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 type Actions struct {
@@ -41,11 +39,12 @@ func (t *Actions) New(property *base.Property) *Actions {
 	t.Info = new(info.Actions)
 	property.Info = t.Info
 	t.Base = new(base.Base).New(property)
-	if strings.EqualFold(t.Vendor, vendor.Github.String()) {
+	if t.IsVendor(vendor.Github) {
 		t.EndpointReposActionsGithub()
 	} else {
 		t.EndpointRepos()
 	}
+	t.Require(base.FeatureActions)
 	return t
 }
 
@@ -57,7 +56,7 @@ func (t *Actions) Get() *Actions {
 func (t *Actions) Set(enable bool) *Actions {
 	t.Info.Set(enable)
 	switch t.Vendor {
-	case "github":
+	case vendor.Github:
 		t.SetPut()
 	default:
 		t.SetPatch()
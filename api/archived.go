@@ -23,8 +23,11 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"path"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 type Archived struct {
@@ -34,7 +37,7 @@ type Archived struct {
 
 func (t *Archived) New(property *base.Property) *Archived {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointRepos()
+	t.Base = new(base.Base).New(property).EndpointRepos().Require(base.FeatureArchived)
 	return t
 }
 
@@ -43,8 +46,22 @@ func (t *Archived) Get() *Archived {
 	return t
 }
 
+// Gitlab: POST /projects/OWNER%2FREPO/archive or unarchive
 func (t *Archived) Set(enable bool) *Archived {
 	t.Info.Archived = enable
-	t.SetPatch()
+	t.SetDestructive("")
+	if enable {
+		t.SetDestructive(base.OpArchive)
+	}
+	if t.IsVendor(vendor.Gitlab) {
+		action := "unarchive"
+		if enable {
+			action = "archive"
+		}
+		t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, action)
+		t.SetPost()
+	} else {
+		t.SetPatch()
+	}
 	return t
 }
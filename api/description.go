@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository description structure
@@ -48,6 +48,6 @@ func (t *Description) Get() *Description {
 
 func (t *Description) Set(description string) *Description {
 	t.Info.Description = description
-	t.SetPatch()
+	t.SetEdit()
 	return t
 }
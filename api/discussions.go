@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository Discussions structure
@@ -37,7 +37,7 @@ type Discussions struct {
 
 func (t *Discussions) New(property *base.Property) *Discussions {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointRepos()
+	t.Base = new(base.Base).New(property).EndpointRepos().Require(base.FeatureDiscussions)
 	return t
 }
 
@@ -48,6 +48,6 @@ func (t *Discussions) Get() *Discussions {
 
 func (t *Discussions) Set(enable bool) *Discussions {
 	t.Info.Has = enable
-	t.SetPatch()
+	t.SetEdit()
 	return t
 }
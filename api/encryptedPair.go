@@ -25,15 +25,22 @@ THE SOFTWARE.
 package api
 
 import (
+	"context"
+	"errors"
 	"path"
 
 	"github.com/J-Siu/go-crypto/crypto"
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository action secret structure
 // Do() handles public key
+//
+// Gitea, Forgejo: secret value is not encrypted
+//
+// Gitlab: CI/CD variable, value is not encrypted
 type EncryptedPair struct {
 	*base.Base
 	Info  info.EncryptedPair
@@ -43,7 +50,7 @@ type EncryptedPair struct {
 
 func (t *EncryptedPair) New(property *base.Property) *EncryptedPair {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointReposSecrets()
+	t.Base = new(base.Base).New(property).EndpointReposSecrets().Require(base.FeatureSecrets)
 	return t
 }
 
@@ -57,27 +64,64 @@ func (t *EncryptedPair) Set(name, value string) *EncryptedPair {
 
 // Do() handles public key
 func (t *EncryptedPair) Do() *base.Base {
+	return t.DoContext(context.Background())
+}
+
+// DoContext() handles public key, ctx is used for both public key and secret requests
+func (t *EncryptedPair) DoContext(ctx context.Context) *base.Base {
+	switch {
+	case t.Unsupported() != nil:
+		return t.Base.DoContext(ctx)
+	case t.IsVendor(vendor.Gitlab):
+		return t.doGitlab(ctx)
+	case t.IsVendor(vendor.Gitea, vendor.Forgejo):
+		t.Info = info.EncryptedPair{Data: t.value}
+		return t.Base.DoContext(ctx)
+	}
 	// Get public key -- start
 	var (
-		publicKey = new(PublicKey).New(t.Property)
+		publicKey = new(PublicKey).New(t.Property).Get()
 	)
-	if !publicKey.Do().Ok() {
+	if !publicKey.DoContext(ctx).Ok() {
 		return publicKey.Base
 	}
 	// Get public key -- end
-	t.encrypt(&publicKey.Info)
-	if *t.Err() == "" {
-		t.Base.Do()
+	if t.DryRun {
+		// Value cannot be encrypted without public key
+		t.Info = info.EncryptedPair{Encrypted_value: base.Redacted}
+		return t.Base.DoContext(ctx).PlanPrepend(publicKey.Plan())
+	}
+	if e := t.encrypt(&publicKey.Info); e != nil {
+		return t.SetErr(e)
+	}
+	return t.Base.DoContext(ctx)
+}
+
+// Update gitlab CI/CD variable, create it if not found
+func (t *EncryptedPair) doGitlab(ctx context.Context) *base.Base {
+	endpoint := t.Req.Endpoint
+	t.Info = info.EncryptedPair{Value: t.value}
+	if !errors.Is(t.Base.DoContext(ctx).Err(), base.ErrNotFound) && !t.DryRun {
+		return t.Base
+	}
+	put := t.Plan()
+	t.Info.Key = t.name
+	t.Req.Endpoint = path.Dir(endpoint)
+	if t.SetPost().DoContext(ctx).Plan() != nil {
+		t.Plan().Steps[0].Note = "if previous step returns 404"
+		t.PlanPrepend(put)
 	}
+	// Restore for next Do()
+	t.Req.Endpoint = endpoint
+	t.SetPut()
 	return t.Base
 }
 
-func (t *EncryptedPair) encrypt(pk *info.PublicKey) *EncryptedPair {
+func (t *EncryptedPair) encrypt(pk *info.PublicKey) error {
 	t.Info.Key_id = pk.Key_id
 	encrypted_value, e := crypto.BoxSealAnonymous(&pk.Key, &t.value)
-	t.Info.Encrypted_value = *encrypted_value
-	if e != nil {
-		t.Res.Err = e.Error()
+	if e == nil {
+		t.Info.Encrypted_value = *encrypted_value
 	}
-	return t
+	return e
 }
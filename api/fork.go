@@ -0,0 +1,119 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package api
+
+import (
+	"context"
+	"iter"
+	"path"
+	"strconv"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Repository forks
+type Fork struct {
+	*base.Base
+	Info  info.Fork     // request and result of Create()
+	Forks info.InfoList // result of Get(), Iter()
+	Merge info.ForkSync // request and result of Sync()
+	page  int
+}
+
+func (t *Fork) New(property *base.Property) *Fork {
+	property.Info = &t.Info
+	t.Base = new(base.Base).New(property)
+	t.page = 1
+	return t
+}
+
+// Set action: get forks of repository, page starts from 1
+func (t *Fork) Get(page int) *Fork {
+	t.page = page
+	t.Req.UrlVal = nil
+	t.list()
+	return t
+}
+
+// Iterate forks of all pages, starting from page given to Get(), or 1
+func (t *Fork) Iter(ctx context.Context) iter.Seq2[info.Repository, error] {
+	return func(yield func(info.Repository, error) bool) {
+		t.list()
+		base.Iter(ctx, t.Base, &t.Forks)(yield)
+	}
+}
+
+// Set action: fork repository into organization, empty for authenticated user.
+// Name is the fork name, empty to keep upstream name.
+//
+// Github: defaultBranchOnly copies default branch only, not supported by others
+func (t *Fork) Create(org, name string, defaultBranchOnly bool) *Fork {
+	t.SetUnsupported("")
+	t.Info = info.Fork{Organization: org, Name: name, DefaultBranchOnly: defaultBranchOnly, Vendor: t.Vendor}
+	t.SetInfo(&t.Info)
+	t.endpoint().SetPost()
+	t.Req.UrlVal = nil
+	if t.IsVendor(vendor.Gitlab) {
+		t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "fork")
+	}
+	if defaultBranchOnly && !t.IsVendor(vendor.Github) {
+		t.SetUnsupported("fork default branch only")
+	}
+	return t
+}
+
+// Set action: sync branch of fork with upstream
+//
+// Gitea: 1.24+, Forgejo, Gitlab, Gogs: not supported
+func (t *Fork) Sync(branch string) *Fork {
+	t.SetUnsupported("")
+	t.Merge = info.ForkSync{Branch: branch}
+	t.SetInfo(&t.Merge)
+	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "merge-upstream")
+	t.Req.UrlVal = nil
+	t.SetPost().Require(base.FeatureForkSync)
+	return t
+}
+
+// Set request of forks list from page, 100 per page unless already set
+func (t *Fork) list() {
+	t.SetUnsupported("")
+	t.SetInfo(&t.Forks)
+	t.endpoint().SetGet()
+	if t.PerPage() == 0 {
+		t.Req.UrlValInit()
+		t.Req.UrlVal.Add("per_page", strconv.Itoa(100)) // github, gitlab
+		t.Req.UrlVal.Add("limit", strconv.Itoa(100))    // gitea
+	}
+	t.SetPage(t.page)
+}
+
+// Initialize endpoint /repos/OWNER/REPO/forks
+func (t *Fork) endpoint() *base.Base {
+	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "forks")
+	return t.Base
+}
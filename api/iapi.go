@@ -24,12 +24,17 @@ THE SOFTWARE.
 
 package api
 
-import "github.com/J-Siu/go-gitapi/v3/base"
+import (
+	"context"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
 
 // gitapi interface
 type IApi interface {
 	Do() *base.Base
-	Err() *string
+	DoContext(ctx context.Context) *base.Base
+	Err() error
 	Name() string
 	Ok() bool
 	Output() *string
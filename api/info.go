@@ -25,16 +25,18 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
-// Github repository(creation) info structure
+// Repository info
 type Info struct {
 	*base.Base
+	Info info.Repository
 }
 
 func (t *Info) New(property *base.Property) *Info {
-	property.Info = nil
+	property.Info = &t.Info
 	t.Base = new(base.Base).New(property).EndpointRepos()
 	return t
 }
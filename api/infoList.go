@@ -25,26 +25,34 @@ THE SOFTWARE.
 package api
 
 import (
+	"context"
+	"iter"
 	"strconv"
 
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository(creation) info structure
 type InfoList struct {
 	*base.Base
 	Info info.InfoList
+	page int
 }
 
 func (t *InfoList) New(property *base.Property, page int) *InfoList {
 	property.Info = &t.Info
 	t.Base = new(base.Base).New(property).EndpointUserRepos()
+	t.page = page
 
 	t.Req.UrlValInit()
 	t.Req.UrlVal.Add("per_page", strconv.Itoa(100)) // github
 	t.Req.UrlVal.Add("limit", strconv.Itoa(100))    //gitea
 	t.Req.UrlVal.Add("page", strconv.Itoa(page))
+	if t.IsVendor(vendor.Gitlab) {
+		t.Req.UrlVal.Add("membership", "true")
+	}
 	*t.Repo() = ""
 	return t
 }
@@ -53,3 +61,34 @@ func (t *InfoList) Get() *InfoList {
 	t.SetGet()
 	return t
 }
+
+// Iterate repositories of all pages, starting from page given to New()
+func (t *InfoList) Iter(ctx context.Context) iter.Seq2[info.Repository, error] {
+	return func(yield func(info.Repository, error) bool) {
+		t.SetPage(t.page)
+		base.Iter(ctx, t.Base, &t.Info)(yield)
+	}
+}
+
+// Get repositories of all pages into Info, with context.Background()
+func (t *InfoList) DoAll() *base.Base {
+	return t.DoAllContext(context.Background())
+}
+
+// Get repositories of all pages into Info.
+//
+// On failure, Info contains repositories received before the failed page.
+func (t *InfoList) DoAllContext(ctx context.Context) *base.Base {
+	var list info.InfoList
+	for i, e := range t.Iter(ctx) {
+		if e != nil {
+			break
+		}
+		list = append(list, i)
+	}
+	t.Info = list
+	if t.Ok() {
+		t.Res.Output = t.Info.StringP()
+	}
+	return t.Base
+}
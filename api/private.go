@@ -25,8 +25,9 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository private structure
@@ -38,6 +39,9 @@ type Private struct {
 func (t *Private) New(property *base.Property) *Private {
 	property.Info = &t.Info
 	t.Base = new(base.Base).New(property).EndpointRepos()
+	if t.IsVendor(vendor.Gitlab) {
+		t.SetUnsupported("private, use visibility")
+	}
 	return t
 }
 
@@ -48,6 +52,10 @@ func (t *Private) Get() *Private {
 
 func (t *Private) Set(enable bool) *Private {
 	t.Info.Private = enable
-	t.SetPatch()
+	t.SetDestructive("")
+	if !enable {
+		t.SetDestructive(base.OpMakePublic)
+	}
+	t.SetEdit()
 	return t
 }
@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository has_projects structure
@@ -37,7 +37,7 @@ type Projects struct {
 
 func (t *Projects) New(property *base.Property) *Projects {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointRepos()
+	t.Base = new(base.Base).New(property).EndpointRepos().Require(base.FeatureProjects)
 	return t
 }
 
@@ -47,7 +47,7 @@ func (t *Projects) Get() *Projects {
 }
 
 func (t *Projects) Set(enable bool) *Projects {
-	t.Info.Has=enable
-	t.SetPatch()
+	t.Info.Has = enable
+	t.SetEdit()
 	return t
 }
@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository public key structure
@@ -37,7 +37,7 @@ type PublicKey struct {
 
 func (t *PublicKey) New(property *base.Property) *PublicKey {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointReposSecretsPubkey()
+	t.Base = new(base.Base).New(property).EndpointReposSecretsPubkey().Require(base.FeaturePublicKey)
 	return t
 }
 
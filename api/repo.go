@@ -25,16 +25,25 @@ THE SOFTWARE.
 package api
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"path"
 
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository(creation) info structure
 type Repo struct {
 	*base.Base
-	Info info.Info
+	Info     info.Info
+	Template info.Template // request of Generate()
+	Move     info.Transfer // request of Rename(), Transfer(), TransferAccept(), TransferReject()
+	create   bool          // Create() or CreateOrg()
+	move     bool          // Property is updated after Rename() or Transfer*()
+	owner    string        // organization of CreateOrg(), Property.User if empty
 }
 
 func (t *Repo) New(property *base.Property) *Repo {
@@ -45,20 +54,239 @@ func (t *Repo) New(property *base.Property) *Repo {
 
 // Set action: create
 func (t *Repo) Create() *Repo {
+	t.SetUnsupported("")
+	t.SetDestructive("")
+	t.create, t.move, t.owner = true, false, ""
+	t.SetInfo(&t.Info)
 	t.EndpointUserRepos().SetPost()
 	return t
 }
 
-// Set action: delete
+// Set action: create in organization
+//
+// Gitlab: not supported
+func (t *Repo) CreateOrg(org string) *Repo {
+	t.SetUnsupported("")
+	t.SetDestructive("")
+	t.create, t.move, t.owner = true, false, org
+	t.SetInfo(&t.Info)
+	t.Req.Endpoint = path.Join("orgs", org, "repos")
+	t.SetPost()
+	if t.IsVendor(vendor.Gitlab) {
+		t.SetUnsupported("create in organization")
+	}
+	return t
+}
+
+// Set action: delete, destructive operation, see base.Guard
 func (t *Repo) Del() *Repo {
-	t.Base.Info = nil
-	t.Base.Api.Info = nil
-	t.EndpointRepos().SetDel()
+	t.SetUnsupported("")
+	t.create, t.move = false, false
+	t.SetInfo(nil)
+	t.EndpointRepos().SetDel().SetDestructive(base.OpDeleteRepo)
 	return t
 }
 
+// Set action: delete secret, destructive operation, see base.Guard
+//
+// Gitlab: delete CI/CD variable
 func (t *Repo) DelSecret(secret string) *Repo {
-	t.EndpointReposSecrets().SetDel()
+	t.SetUnsupported("")
+	t.create, t.move = false, false
+	t.SetInfo(nil)
+	t.EndpointReposSecrets().SetDel().SetDestructive(base.OpDeleteSecret).Require(base.FeatureSecrets)
 	t.Req.Endpoint = path.Join(t.Req.Endpoint, secret)
 	return t
 }
+
+// Set action: generate repository from template repository owner/template, options and result in Template
+//
+// Gitlab, Gogs: not supported
+func (t *Repo) Generate(owner, template string) *Repo {
+	t.SetUnsupported("")
+	t.SetDestructive("")
+	t.create, t.move = false, false
+	t.SetInfo(&t.Template)
+	t.Req.Endpoint = path.Join("repos", owner, template, "generate")
+	t.SetPost()
+	if t.IsVendor(vendor.Gitlab, vendor.Gogs) {
+		t.SetUnsupported("generate from template")
+	}
+	return t
+}
+
+// Set action: rename repository, Property.Repo is updated on success
+//
+// Gitlab: name and path are changed
+func (t *Repo) Rename(name string) *Repo {
+	t.setMove(info.Transfer{Name: name})
+	t.SetDestructive("")
+	t.EndpointRepos().SetEdit()
+	return t
+}
+
+// Set action: transfer repository to owner, destructive operation, see base.Guard.
+// Property.User and Property.Repo are updated to repository of response.
+// Name is the new repository name, empty to keep.
+//
+// Gitea: transfer to user other than self is pending until accepted, name not supported.
+// Gitlab: owner is namespace, name and teamIds not supported.
+// Gogs: not supported
+func (t *Repo) Transfer(owner, name string, teamIds ...int64) *Repo {
+	t.setMove(info.Transfer{NewOwner: owner, NewName: name, TeamIds: teamIds})
+	t.SetDestructive(base.OpTransfer)
+	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "transfer")
+	t.SetPost()
+	switch {
+	case t.IsVendor(vendor.Gogs):
+		t.SetUnsupported("transfer")
+	case t.IsVendor(vendor.Gitlab) && (name != "" || len(teamIds) > 0):
+		t.SetUnsupported("transfer with name or teams")
+	case t.IsVendor(vendor.Gitlab):
+		t.SetPut()
+	case name != "" && !t.IsVendor(vendor.Github):
+		t.SetUnsupported("transfer with name")
+	}
+	return t
+}
+
+// Set action: accept pending transfer of repository
+//
+// Gitea, Forgejo only
+func (t *Repo) TransferAccept() *Repo {
+	return t.transferPending("accept")
+}
+
+// Set action: reject pending transfer of repository
+//
+// Gitea, Forgejo only
+func (t *Repo) TransferReject() *Repo {
+	return t.transferPending("reject")
+}
+
+func (t *Repo) transferPending(action string) *Repo {
+	t.setMove(info.Transfer{})
+	t.SetDestructive("")
+	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "transfer", action)
+	t.SetPost()
+	if !t.IsVendor(vendor.Gitea, vendor.Forgejo) {
+		t.SetUnsupported("transfer " + action)
+	}
+	return t
+}
+
+func (t *Repo) setMove(move info.Transfer) {
+	t.SetUnsupported("")
+	t.create, t.move = false, true
+	t.Move = move
+	t.SetInfo(&t.Move)
+}
+
+func (t *Repo) Do() *base.Base {
+	return t.DoContext(context.Background())
+}
+
+// Create: request body is mapped per vendor by info.Info, options not accepted on creation are set afterwards.
+//
+// Gitlab: Info.Visibility, from Info.Private if empty, is used on create
+func (t *Repo) DoContext(ctx context.Context) *base.Base {
+	t.Info.Vendor = t.Vendor
+	t.Template.Vendor = t.Vendor
+	if t.Template.Owner == "" {
+		t.Template.Owner = t.User
+	}
+	t.Move.Vendor = t.Vendor
+	if t.move {
+		return t.doMove(ctx)
+	}
+	if !t.create || t.Unsupported() != nil {
+		return t.Base.DoContext(ctx)
+	}
+	if t.IsVendor(vendor.Gitlab) {
+		if t.Info.GitignoreTemplate != "" || t.Info.LicenseTemplate != "" {
+			return t.SetErr(fmt.Errorf("%w: gitignore/license template on %s", base.ErrUnsupported, t.Vendor))
+		}
+	}
+	// Response is decoded into Info, pointer fields are copied to keep options and caller values
+	options := t.Info
+	t.Info.HasIssues, t.Info.HasProjects, t.Info.HasWiki = boolCopy(options.HasIssues), boolCopy(options.HasProjects), boolCopy(options.HasWiki)
+	if !t.Base.DoContext(ctx).Ok() {
+		return t.Base
+	}
+	var (
+		edit = &info.Object{}
+		next *base.Base
+	)
+	switch {
+	case t.IsVendor(vendor.Gitea, vendor.Forgejo, vendor.Gogs):
+		// Options not in gitea create option
+		for k, v := range map[string]*bool{"has_issues": options.HasIssues, "has_projects": options.HasProjects, "has_wiki": options.HasWiki} {
+			if v != nil {
+				(*edit)[k] = *v
+			}
+		}
+		if options.Homepage != "" {
+			(*edit)["website"] = options.Homepage
+		}
+		if len(*edit) == 0 {
+			return t.Base
+		}
+		next = t.next(edit).EndpointRepos().SetPatch()
+	case t.IsVendor(vendor.Github):
+		// Github creates default branch "main" or user setting with AutoInit
+		created := t.Info.DefaultBranch
+		if t.DryRun {
+			created = "main"
+		}
+		if options.DefaultBranch == "" || !options.AutoInit || created == options.DefaultBranch {
+			return t.Base
+		}
+		(*edit)["new_name"] = options.DefaultBranch
+		next = t.next(edit).EndpointRepos()
+		next.Req.Endpoint = path.Join(next.Req.Endpoint, "branches", created, "rename")
+		next.SetPost()
+	default:
+		return t.Base
+	}
+	if next.DoContext(ctx).Plan() != nil {
+		next.Plan().Steps[0].Note = "after create"
+		return next.PlanPrepend(t.Plan())
+	}
+	if e := next.Err(); e != nil {
+		return t.SetErr(errors.Join(fmt.Errorf("repository %s created", t.Info.Name), e))
+	}
+	if options.DefaultBranch != "" {
+		t.Info.DefaultBranch = options.DefaultBranch
+	}
+	return t.Base
+}
+
+// Do rename or transfer, update Property to repository of response
+func (t *Repo) doMove(ctx context.Context) *base.Base {
+	if !t.Base.DoContext(ctx).Ok() || t.Plan() != nil {
+		return t.Base
+	}
+	if full := t.Move.Repository.FullName; full != "" {
+		t.Property.User, t.Property.Repo = path.Dir(full), path.Base(full)
+	}
+	return t.Base
+}
+
+// Return Base of follow-up request on created repository
+func (t *Repo) next(body *info.Object) *base.Base {
+	property := *t.Property
+	property.Info = body
+	property.Repo = t.Info.Name
+	if t.owner != "" {
+		property.User = t.owner
+	}
+	return new(base.Base).New(&property)
+}
+
+func boolCopy(v *bool) *bool {
+	if v == nil {
+		return nil
+	}
+	b := *v
+	return &b
+}
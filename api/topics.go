@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository topics structure
@@ -37,7 +37,7 @@ type Topics struct {
 
 func (t *Topics) New(property *base.Property) *Topics {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointReposTopics()
+	t.Base = new(base.Base).New(property).EndpointReposTopics().Require(base.FeatureTopics)
 	return t
 }
 
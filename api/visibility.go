@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository visibility structure
@@ -49,9 +49,11 @@ func (t *Visibility) Get() *Visibility {
 func (t *Visibility) Set(enable bool) *Visibility {
 	if enable {
 		t.Info.Visibility = "public"
+		t.SetDestructive(base.OpMakePublic)
 	} else {
 		t.Info.Visibility = "private"
+		t.SetDestructive("")
 	}
-	t.SetPatch()
+	t.SetEdit()
 	return t
 }
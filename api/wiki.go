@@ -25,8 +25,8 @@ THE SOFTWARE.
 package api
 
 import (
-	"github.com/J-Siu/go-gitapi/v3/base"
-	"github.com/J-Siu/go-gitapi/v3/info"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/info"
 )
 
 // Github repository Wiki structure
@@ -37,7 +37,7 @@ type Wiki struct {
 
 func (t *Wiki) New(property *base.Property) *Wiki {
 	property.Info = &t.Info
-	t.Base = new(base.Base).New(property).EndpointRepos()
+	t.Base = new(base.Base).New(property).EndpointRepos().Require(base.FeatureWiki)
 	return t
 }
 
@@ -47,7 +47,8 @@ func (t *Wiki) Get() *Wiki {
 }
 
 func (t *Wiki) Set(enable bool) *Wiki {
-	t.Info.Has = enable
-	t.SetPatch()
+	t.Info.Set(enable)
+	t.Info.Vendor = t.Vendor
+	t.SetEdit()
 	return t
 }
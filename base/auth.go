@@ -0,0 +1,116 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Authorization scheme of Property
+type AuthScheme string
+
+const (
+	AuthDefault AuthScheme = ""       // AuthToken, gitlab: PRIVATE-TOKEN header
+	AuthToken   AuthScheme = "token"  // Authorization: token <token>
+	AuthBearer  AuthScheme = "bearer" // Authorization: Bearer <token>
+	AuthBasic   AuthScheme = "basic"  // Authorization: Basic <login:password>, token is used if Password is empty
+)
+
+// Set authorization headers of request, per Property.AuthScheme and vendor.
+//
+// Token is resolved from tokenSource() if set, else Property.Token.
+// Sudo and Otp headers are only set if supported by vendor.
+func (t *Base) authorize(ctx context.Context, header http.Header) error {
+	token := t.Token
+	if source := t.tokenSource(); source != nil {
+		var e error
+		if token, e = source.Token(ctx); e != nil {
+			return errors.Join(ErrAuth, e)
+		}
+	}
+	return t.authHeader(header, token)
+}
+
+// Set authorization headers of token
+func (t *Base) authHeader(header http.Header, token string) error {
+	header.Del("Authorization")
+	header.Del("PRIVATE-TOKEN")
+	switch t.AuthScheme {
+	case AuthDefault, AuthToken:
+		if token == "" {
+			break
+		}
+		if t.AuthScheme == AuthDefault && t.IsVendor(vendor.Gitlab) {
+			header.Set("PRIVATE-TOKEN", token)
+		} else {
+			header.Set("Authorization", "token "+token)
+		}
+	case AuthBearer:
+		if token != "" {
+			header.Set("Authorization", "Bearer "+token)
+		}
+	case AuthBasic:
+		if t.IsVendor(vendor.Gitlab) {
+			return t.authUnsupported("basic auth")
+		}
+		login, password := t.Login, t.Password
+		if login == "" {
+			login = t.User
+		}
+		if password == "" {
+			password = token
+		}
+		req := http.Request{Header: header}
+		req.SetBasicAuth(login, password)
+	default:
+		return fmt.Errorf("%w: unknown auth scheme %q", ErrAuth, t.AuthScheme)
+	}
+	if t.Sudo != "" {
+		if t.IsVendor(vendor.Github) {
+			return t.authUnsupported("sudo")
+		}
+		header.Set("Sudo", t.Sudo)
+	}
+	if t.Otp != "" {
+		switch {
+		case t.IsVendor(vendor.Github):
+			header.Set("X-GitHub-OTP", t.Otp)
+		case t.IsVendor(vendor.Gitea, vendor.Forgejo):
+			header.Set("X-Gitea-OTP", t.Otp)
+		default:
+			return t.authUnsupported("otp")
+		}
+	}
+	return nil
+}
+
+func (t *Base) authUnsupported(operation string) error {
+	return errors.Join(ErrAuth, fmt.Errorf("%w: %s on %s", ErrUnsupported, operation, t.Vendor))
+}
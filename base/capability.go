@@ -0,0 +1,222 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Optional api feature, not supported by all vendors or versions
+type Feature string
+
+const (
+	FeatureActions     Feature = "actions"
+	FeatureArchived    Feature = "archived"
+	FeatureDiscussions Feature = "discussions"
+	FeatureForkSync    Feature = "fork_sync" // sync fork branch with upstream
+	FeatureProjects    Feature = "projects"
+	FeaturePublicKey   Feature = "public_key" // public key for encrypted secret
+	FeatureSecrets     Feature = "secrets"    // gitlab: CI/CD variables
+	FeatureTopics      Feature = "topics"
+	FeatureWiki        Feature = "wiki"
+)
+
+// Minimum gitea version(gitea, forgejo) of features, gitea only
+var giteaFeatureVersion = map[Feature]string{
+	FeatureActions:  "1.21",
+	FeatureArchived: "1.9",
+	FeatureForkSync: "1.24",
+	FeatureProjects: "1.19",
+	FeatureSecrets:  "1.21",
+	FeatureTopics:   "1.8",
+}
+
+// Features not supported by vendor, regardless of version
+var vendorUnsupported = map[vendor.Vendor][]Feature{
+	vendor.Gitea:   {FeatureDiscussions, FeaturePublicKey},
+	vendor.Forgejo: {FeatureDiscussions, FeatureForkSync, FeaturePublicKey},
+	vendor.Gitlab:  {FeatureActions, FeatureDiscussions, FeatureForkSync, FeatureProjects, FeaturePublicKey},
+	vendor.Gogs:    {FeatureActions, FeatureArchived, FeatureDiscussions, FeatureForkSync, FeatureProjects, FeaturePublicKey, FeatureSecrets, FeatureTopics, FeatureWiki},
+}
+
+// Server capability
+type Capability struct {
+	Vendor           vendor.Vendor    `json:"vendor"`
+	Version          string           `json:"version,omitempty"`            // server version, empty if unknown
+	Features         map[Feature]bool `json:"features,omitempty"`           // supported features
+	MaxResponseItems int              `json:"max_response_items,omitempty"` // gitea, forgejo max items per page
+}
+
+// Return capability of vendor and server version.
+// Features are decided by vendor only if version is empty.
+func NewCapability(v vendor.Vendor, version string) *Capability {
+	t := Capability{
+		Vendor:   v,
+		Version:  version,
+		Features: make(map[Feature]bool),
+	}
+	for _, f := range []Feature{FeatureActions, FeatureArchived, FeatureDiscussions, FeatureForkSync, FeatureProjects, FeaturePublicKey, FeatureSecrets, FeatureTopics, FeatureWiki} {
+		t.Features[f] = true
+		if minVersion, ok := giteaFeatureVersion[f]; ok && (v == vendor.Gitea || v == vendor.Forgejo) && version != "" {
+			t.Features[f] = versionAtLeast(giteaVersion(version), minVersion)
+		}
+	}
+	for _, f := range vendorUnsupported[v] {
+		t.Features[f] = false
+	}
+	return &t
+}
+
+// Check if feature is supported
+func (t *Capability) Supports(feature Feature) bool {
+	supported, ok := t.Features[feature]
+	return !ok || supported
+}
+
+// Probe server for version and settings, and save result in property.Capability.
+//
+//   - github: GET /meta (enterprise server "installed_version")
+//   - gitea, forgejo, gogs: GET /version, GET /settings/api
+//   - gitlab: GET /version
+func Probe(ctx context.Context, property *Property) (*Capability, error) {
+	var (
+		version string
+		p       = *property
+	)
+	p.Info = nil
+	p.Capability = nil
+
+	get := func(endpoint string, v any) error {
+		b := New(&p)
+		b.Req.Endpoint = endpoint
+		if e := b.SetGet().DoContext(ctx).Err(); e != nil {
+			return e
+		}
+		return json.Unmarshal(*b.Res.Body, v)
+	}
+
+	switch p.Vendor {
+	case vendor.Github:
+		var meta struct {
+			InstalledVersion string `json:"installed_version"`
+		}
+		if e := get("meta", &meta); e != nil {
+			return nil, e
+		}
+		version = meta.InstalledVersion
+	default:
+		var res struct {
+			Version string `json:"version"`
+		}
+		// gogs has no version endpoint
+		if e := get("version", &res); e != nil && !errors.Is(e, ErrNotFound) {
+			return nil, e
+		}
+		version = res.Version
+	}
+	capability := NewCapability(p.Vendor, version)
+
+	if p.Vendor == vendor.Gitea || p.Vendor == vendor.Forgejo {
+		var settings struct {
+			MaxResponseItems int `json:"max_response_items"`
+		}
+		if get("settings/api", &settings) == nil {
+			capability.MaxResponseItems = settings.MaxResponseItems
+		}
+	}
+	property.Capability = capability
+	return capability, nil
+}
+
+// Mark operation unsupported, if feature is not supported by Property.Capability, or by vendor if Capability is nil
+func (t *Base) Require(feature Feature) *Base {
+	capability := t.Capability
+	if capability == nil {
+		capability = NewCapability(t.Vendor, "")
+	}
+	if !capability.Supports(feature) {
+		operation := string(feature)
+		if capability.Version != "" {
+			operation += fmt.Sprintf(" (version %s)", capability.Version)
+		}
+		t.SetUnsupported(operation)
+	}
+	return t
+}
+
+// Gitea version of gitea, forgejo version string
+//
+//	"1.21.3" -> "1.21.3"
+//	"7.0.5+gitea-1.21.11" -> "1.21.11"
+func giteaVersion(version string) string {
+	if _, after, found := strings.Cut(version, "+gitea-"); found {
+		return after
+	}
+	return version
+}
+
+// Check if version >= minVersion, compare numerically by "." separated parts
+func versionAtLeast(version, minVersion string) bool {
+	var (
+		v = versionParts(version)
+		m = versionParts(minVersion)
+	)
+	for i := range m {
+		if i >= len(v) || v[i] < m[i] {
+			return false
+		}
+		if v[i] > m[i] {
+			return true
+		}
+	}
+	return true
+}
+
+// Leading numeric parts of version, "v1.21.3-rc1" -> [1 21 3]
+func versionParts(version string) []int {
+	var parts []int
+	for _, s := range strings.Split(strings.TrimPrefix(version, "v"), ".") {
+		end := strings.IndexFunc(s, func(r rune) bool { return r < '0' || r > '9' })
+		if end == 0 {
+			break
+		}
+		if end > 0 {
+			s = s[:end]
+		}
+		n, _ := strconv.Atoi(s)
+		parts = append(parts, n)
+		if end > 0 {
+			break
+		}
+	}
+	return parts
+}
@@ -0,0 +1,162 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Returned by Detect() if vendor cannot be detected
+var ErrDetect = errors.New("vendor not detected")
+
+// Api base path of vendors
+const (
+	ApiPathGithub = "/api/v3" // github enterprise server
+	ApiPathGitea  = "/api/v1" // gitea, forgejo, gogs
+	ApiPathGitlab = "/api/v4"
+)
+
+// Detect vendor and api base path of property.EntryPoint, and save them in
+// property.Vendor and property.EntryPoint.
+//
+// EntryPoint can be the web url or api url of the server. Following are probed in order:
+//
+//   - github.com, api.github.com: no request
+//   - github enterprise server: GET /api/v3/meta
+//   - gitea, forgejo: GET /api/v1/version, forgejo: GET /api/forgejo/v1/version
+//   - gitlab: GET /api/v4/version, 401 is accepted as it requires authentication
+//
+// Gogs cannot be detected, property.Vendor must be set manually.
+func Detect(ctx context.Context, property *Property) error {
+	u, e := url.Parse(property.EntryPoint)
+	if e != nil {
+		return e
+	}
+	if u.Host == "" {
+		return errors.Join(ErrDetect, errors.New("entry point has no host: "+property.EntryPoint))
+	}
+	if u.Host == "github.com" || u.Host == "api.github.com" {
+		property.EntryPoint = "https://api.github.com"
+		property.Vendor = vendor.Github
+		return nil
+	}
+	// Strip known api path to get server root
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	for _, apiPath := range []string{ApiPathGithub, ApiPathGitea, ApiPathGitlab} {
+		u.Path = strings.TrimSuffix(u.Path, apiPath)
+	}
+	u.RawPath = ""
+	root := u.String()
+
+	for _, probe := range []func(context.Context, *Property, string) (vendor.Vendor, string, bool){
+		detectGithub,
+		detectGitea,
+		detectGitlab,
+	} {
+		if v, entryPoint, ok := probe(ctx, property, root); ok {
+			property.EntryPoint = entryPoint
+			property.Vendor = v
+			return nil
+		}
+		if e = ctx.Err(); e != nil {
+			return e
+		}
+	}
+	return errors.Join(ErrDetect, errors.New(root))
+}
+
+// GET entryPoint/endpoint with property vendor v, return status code and body
+func detectGet(ctx context.Context, property *Property, v vendor.Vendor, entryPoint, endpoint string) (int, []byte) {
+	p := *property
+	p.Capability = nil
+	p.EntryPoint = entryPoint
+	p.Info = nil
+	p.RateLimitPolicy = nil
+	p.RetryPolicy = nil
+	p.Vendor = v
+	b := New(&p)
+	b.Req.Endpoint = endpoint
+	b.SetGet().DoContext(ctx)
+	if b.Res.Body == nil {
+		return b.StatusCode(), nil
+	}
+	return b.StatusCode(), *b.Res.Body
+}
+
+// Github enterprise server
+func detectGithub(ctx context.Context, property *Property, root string) (vendor.Vendor, string, bool) {
+	var meta struct {
+		InstalledVersion                 *string `json:"installed_version"`
+		VerifiablePasswordAuthentication *bool   `json:"verifiable_password_authentication"`
+	}
+	entryPoint := root + ApiPathGithub
+	status, body := detectGet(ctx, property, vendor.Github, entryPoint, "meta")
+	ok := status == http.StatusOK && json.Unmarshal(body, &meta) == nil &&
+		(meta.InstalledVersion != nil || meta.VerifiablePasswordAuthentication != nil)
+	return vendor.Github, entryPoint, ok
+}
+
+// Gitea, Forgejo
+func detectGitea(ctx context.Context, property *Property, root string) (vendor.Vendor, string, bool) {
+	var res struct {
+		Version *string `json:"version"`
+	}
+	entryPoint := root + ApiPathGitea
+	status, body := detectGet(ctx, property, vendor.Gitea, entryPoint, "version")
+	if status != http.StatusOK || json.Unmarshal(body, &res) != nil || res.Version == nil {
+		return vendor.Gitea, entryPoint, false
+	}
+	if strings.Contains(*res.Version, "+gitea-") {
+		return vendor.Forgejo, entryPoint, true
+	}
+	if status, _ = detectGet(ctx, property, vendor.Forgejo, root+"/api/forgejo/v1", "version"); status == http.StatusOK {
+		return vendor.Forgejo, entryPoint, true
+	}
+	return vendor.Gitea, entryPoint, true
+}
+
+// Gitlab
+func detectGitlab(ctx context.Context, property *Property, root string) (vendor.Vendor, string, bool) {
+	var res struct {
+		Version  *string `json:"version"`
+		Revision *string `json:"revision"`
+		Message  *string `json:"message"`
+	}
+	entryPoint := root + ApiPathGitlab
+	status, body := detectGet(ctx, property, vendor.Gitlab, entryPoint, "version")
+	if json.Unmarshal(body, &res) != nil {
+		return vendor.Gitlab, entryPoint, false
+	}
+	ok := status == http.StatusOK && res.Version != nil && res.Revision != nil ||
+		status == http.StatusUnauthorized && res.Message != nil
+	return vendor.Gitlab, entryPoint, ok
+}
@@ -0,0 +1,254 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"encoding/json"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Sentinel errors, for use with errors.Is()
+var (
+	ErrBadRequest   = errors.New("bad request")           // http 400
+	ErrUnauthorized = errors.New("unauthorized")          // http 401
+	ErrForbidden    = errors.New("forbidden")             // http 403
+	ErrNotFound     = errors.New("not found")             // http 404
+	ErrConflict     = errors.New("conflict")              // http 409
+	ErrValidation   = errors.New("validation failed")     // http 422
+	ErrRateLimited  = errors.New("rate limited")          // http 429, or 403 with rate limit exhausted
+	ErrServer       = errors.New("server error")          // http 5xx
+	ErrTransport    = errors.New("transport error")       // no http response
+	ErrAuth         = errors.New("authentication failed") // failed to get credential
+	ErrApi          = errors.New("api error")             // other api failure
+	ErrUnsupported  = errors.New("unsupported")           // operation not supported by vendor
+)
+
+// Github/Gitea error body field error
+type ErrorField struct {
+	Resource string `json:"resource,omitempty"`
+	Field    string `json:"field,omitempty"`
+	Code     string `json:"code,omitempty"`
+	Message  string `json:"message,omitempty"`
+}
+
+// Field error can be an object(github) or a string(gitea)
+func (t *ErrorField) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		return json.Unmarshal(data, &t.Message)
+	}
+	type errorField ErrorField
+	return json.Unmarshal(data, (*errorField)(t))
+}
+
+func (t *ErrorField) String() string {
+	var str string
+	if t.Field != "" {
+		str = t.Field + ": "
+	}
+	if t.Message != "" {
+		str += t.Message
+	} else {
+		str += t.Code
+	}
+	return str
+}
+
+// Api error, returned by Base.Err()
+type Error struct {
+	Status   int           `json:"-"` // http status code, 0 if there is no response
+	Vendor   vendor.Vendor `json:"-"`
+	Method   string        `json:"-"`
+	Endpoint string        `json:"-"`
+	Err      error         `json:"-"` // underlying error, eg. transport, encryption
+
+	kind error // sentinel error, decided by Status if nil
+
+	// Github/Gitea/Gitlab error body
+	Message          string       `json:"message"` // gitlab field messages are put in Errors
+	DocumentationUrl string       `json:"documentation_url"`
+	Url              string       `json:"url"` // Gitea documentation url
+	Errors           []ErrorField `json:"errors"`
+}
+
+// Message can be a string, or an object of field messages(gitlab), eg. {"name":["has already been taken"]}
+func (t *Error) UnmarshalJSON(data []byte) error {
+	type apiError Error
+	aux := struct {
+		*apiError
+		Message json.RawMessage `json:"message"`
+	}{apiError: (*apiError)(t)}
+	if e := json.Unmarshal(data, &aux); e != nil {
+		return e
+	}
+	var fields map[string]json.RawMessage
+	switch {
+	case len(aux.Message) == 0:
+	case json.Unmarshal(aux.Message, &t.Message) == nil:
+	case json.Unmarshal(aux.Message, &fields) == nil:
+		for _, field := range slices.Sorted(maps.Keys(fields)) {
+			t.Errors = append(t.Errors, ErrorField{Field: field, Message: errorMessage(fields[field])})
+		}
+	default:
+		t.Message = errorMessage(aux.Message)
+	}
+	return nil
+}
+
+// Return message of string or string list, raw json otherwise
+func errorMessage(data json.RawMessage) string {
+	var (
+		message  string
+		messages []string
+	)
+	if json.Unmarshal(data, &message) == nil {
+		return message
+	}
+	if json.Unmarshal(data, &messages) == nil {
+		return strings.Join(messages, ", ")
+	}
+	return string(data)
+}
+
+func (t *Error) Error() string {
+	str := t.Method + " " + t.Endpoint + ": "
+	if t.Status != 0 {
+		str += strconv.Itoa(t.Status) + " "
+	}
+	switch {
+	case t.Message != "":
+		str += t.Message
+	case t.Err != nil:
+		str += t.Err.Error()
+	default:
+		str += t.Sentinel().Error()
+	}
+	if len(t.Errors) > 0 {
+		var fields []string
+		for _, f := range t.Errors {
+			fields = append(fields, f.String())
+		}
+		str += " (" + strings.Join(fields, ", ") + ")"
+	}
+	return str
+}
+
+// Sentinel error of t
+func (t *Error) Sentinel() error {
+	if t.kind != nil {
+		return t.kind
+	}
+	switch {
+	case t.Status == http.StatusBadRequest:
+		return ErrBadRequest
+	case t.Status == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case t.Status == http.StatusForbidden:
+		return ErrForbidden
+	case t.Status == http.StatusNotFound:
+		return ErrNotFound
+	case t.Status == http.StatusConflict:
+		return ErrConflict
+	case t.Status == http.StatusUnprocessableEntity:
+		return ErrValidation
+	case t.Status == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case t.Status >= 500:
+		return ErrServer
+	}
+	return ErrApi
+}
+
+// Allow errors.Is()/errors.As() to match sentinel and underlying error
+func (t *Error) Unwrap() []error {
+	errs := []error{t.Sentinel()}
+	if t.Err != nil {
+		errs = append(errs, t.Err)
+	}
+	return errs
+}
+
+// Create *Error from current request and response
+func (t *Base) newError(err error) *Error {
+	e := Error{
+		Endpoint: t.Req.Endpoint,
+		Err:      err,
+		Method:   t.Method,
+		Status:   t.StatusCode(),
+		Vendor:   t.Vendor,
+	}
+	if t.Res.Body != nil && len(*t.Res.Body) > 0 {
+		json.Unmarshal(*t.Res.Body, &e)
+	}
+	if e.DocumentationUrl == "" {
+		e.DocumentationUrl = e.Url
+	}
+	switch {
+	case errors.Is(err, ErrAuth):
+		e.kind = ErrAuth
+	case e.Status == 0 && err != nil:
+		e.kind = ErrTransport
+	case t.RateLimited():
+		e.kind = ErrRateLimited
+	}
+	if e.Err == nil && e.Message == "" && t.Res.Err != "" {
+		e.Err = errors.New(t.Res.Err)
+	}
+	return &e
+}
+
+// Return http status code of response, 0 if there is no response
+func (t *Base) StatusCode() int {
+	code, _ := strconv.Atoi(strings.SplitN(t.Res.Status, " ", 2)[0])
+	return code
+}
+
+// Set error of t, and mark response as failed.
+//
+// err is wrapped in a new *Error unless it is an *Error itself, wrapped errors are kept for errors.Is()/errors.As().
+func (t *Base) SetErr(err error) *Base {
+	if err == nil {
+		return t
+	}
+	e, ok := err.(*Error)
+	if !ok {
+		e = &Error{
+			Endpoint: t.Req.Endpoint,
+			Err:      err,
+			Method:   t.Method,
+			Vendor:   t.Vendor,
+			kind:     ErrApi,
+		}
+	}
+	t.err = e
+	t.Res.Err = e.Error()
+	return t
+}
@@ -0,0 +1,178 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"path"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Github App token timing
+const (
+	GithubAppJwtExpiry     = 9 * time.Minute // github max is 10 minutes
+	GithubAppTokenRefresh  = 5 * time.Minute // refresh installation token before expiry
+	githubAppJwtClockDrift = time.Minute
+)
+
+// Github App installation access token.
+//
+// Installation token is cached and refreshed before expiry.
+type GithubApp struct {
+	AppId          int64
+	InstallationId int64
+	PrivateKey     string // PEM, PKCS1 or PKCS8 RSA private key
+
+	mutex     sync.Mutex
+	expiresAt time.Time
+	key       *rsa.PrivateKey
+	token     string
+}
+
+// Installation token cache, shared by all Base
+var githubApps sync.Map
+
+// Token source of GithubApp, token is requested with http options of property
+type githubAppSource struct {
+	app      *GithubApp
+	property *Property
+}
+
+func (t githubAppSource) Token(ctx context.Context) (string, error) {
+	return t.app.Token(ctx, t.property)
+}
+
+// Return cached *GithubApp of property
+func githubAppOf(property *Property) *GithubApp {
+	cacheKey := fmt.Sprintf("%s|%d|%d|%x", property.EntryPoint, property.AppId, property.AppInstallationId, sha256.Sum256([]byte(property.AppPrivateKey)))
+	app, _ := githubApps.LoadOrStore(cacheKey, &GithubApp{
+		AppId:          property.AppId,
+		InstallationId: property.AppInstallationId,
+		PrivateKey:     property.AppPrivateKey,
+	})
+	return app.(*GithubApp)
+}
+
+// Return a RS256 JSON Web Token for app authentication
+func (t *GithubApp) Jwt(now time.Time) (string, error) {
+	if t.key == nil {
+		key, e := parseRsaPrivateKey(t.PrivateKey)
+		if e != nil {
+			return "", e
+		}
+		t.key = key
+	}
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	claims, _ := json.Marshal(map[string]any{
+		"iat": now.Add(-githubAppJwtClockDrift).Unix(),
+		"exp": now.Add(GithubAppJwtExpiry).Unix(),
+		"iss": strconv.FormatInt(t.AppId, 10),
+	})
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	signature, e := rsa.SignPKCS1v15(rand.Reader, t.key, crypto.SHA256, hash[:])
+	if e != nil {
+		return "", e
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// Return installation access token, request a new one if not available or about to expire.
+//
+// Token is requested with EntryPoint and http options(Client, Transport, Middleware, Hooks, Tls) of property.
+func (t *GithubApp) Token(ctx context.Context, property *Property) (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != "" && time.Until(t.expiresAt) > GithubAppTokenRefresh {
+		return t.token, nil
+	}
+	jwt, e := t.Jwt(time.Now())
+	if e != nil {
+		return "", e
+	}
+	// POST /app/installations/INSTALLATION_ID/access_tokens
+	p := *property
+	p.AppId = 0
+	p.Capability = nil
+	p.Info = nil
+	p.AuthScheme = AuthBearer
+	p.Otp = ""
+	p.Sudo = ""
+	p.Token = jwt
+	p.TokenSource = nil
+	p.Vendor = vendor.Github
+	b := New(&p)
+	b.Req.Endpoint = path.Join("app", "installations", strconv.FormatInt(t.InstallationId, 10), "access_tokens")
+	if e = b.SetPost().DoContext(ctx).Err(); e != nil {
+		return "", e
+	}
+	var res struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if e = json.Unmarshal(*b.Res.Body, &res); e != nil {
+		return "", e
+	}
+	if res.Token == "" {
+		return "", errors.New("github app: no token in response")
+	}
+	t.token = res.Token
+	t.expiresAt = res.ExpiresAt
+	return t.token, nil
+}
+
+// Parse PEM encoded PKCS1 or PKCS8 RSA private key
+func parseRsaPrivateKey(privateKey string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKey))
+	if block == nil {
+		return nil, errors.New("github app: invalid PEM private key")
+	}
+	if key, e := x509.ParsePKCS1PrivateKey(block.Bytes); e == nil {
+		return key, nil
+	}
+	key, e := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if e != nil {
+		return nil, e
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("github app: private key is not RSA")
+	}
+	return rsaKey, nil
+}
@@ -0,0 +1,150 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"time"
+)
+
+// Returned if destructive operation is not allowed
+var ErrDestructive = errors.New("destructive operation refused")
+
+// Destructive operation
+type Operation string
+
+const (
+	OpArchive      Operation = "archive"
+	OpDeleteRepo   Operation = "delete_repo"
+	OpDeleteSecret Operation = "delete_secret"
+	OpMakePublic   Operation = "make_public"
+	OpTransfer     Operation = "transfer"
+)
+
+// Policy of destructive operations.
+//
+// An operation is allowed by Property.AllowDestructive, Allow, or Confirm, in that order.
+// Repository deletion is refused regardless if repository matches Protected or is pushed within RecentPush.
+type Guard struct {
+	Allow      []Operation   `json:"allow,omitempty"`
+	Protected  []string      `json:"protected,omitempty"`   // path.Match patterns of "OWNER/REPO"
+	RecentPush time.Duration `json:"recent_push,omitempty"` // 0 to disable, repository is fetched before deletion
+
+	// Confirmation callback, target is "OWNER/REPO", or "OWNER/REPO/NAME" for secret
+	Confirm func(ctx context.Context, op Operation, target string) bool `json:"-"`
+}
+
+// Mark request as destructive operation, checked on Do(). Empty op clears it.
+func (t *Base) SetDestructive(op Operation) *Base {
+	t.destructive = op
+	return t
+}
+
+// Return destructive operation of request, empty if not destructive
+func (t *Base) Destructive() Operation {
+	if t.Method == http.MethodGet {
+		return ""
+	}
+	return t.destructive
+}
+
+// Return error if destructive operation of request is not allowed
+func (t *Base) guard(ctx context.Context) error {
+	op := t.Destructive()
+	if op == "" {
+		return nil
+	}
+	target := t.User + "/" + *t.Repo()
+	if op == OpDeleteSecret {
+		target = path.Join(target, path.Base(t.Req.Endpoint))
+	}
+	refuse := func(reason string) error {
+		return fmt.Errorf("%w: %s %s: %s", ErrDestructive, op, target, reason)
+	}
+	guard := t.Guard
+	if guard == nil {
+		guard = new(Guard)
+	}
+	if op == OpDeleteRepo {
+		for _, pattern := range guard.Protected {
+			if match, _ := path.Match(pattern, target); match {
+				return refuse("protected by " + pattern)
+			}
+		}
+		if guard.RecentPush > 0 {
+			pushed, e := t.pushedAt(ctx)
+			if e != nil {
+				return errors.Join(refuse("cannot get last push"), e)
+			}
+			if since := time.Since(pushed); since < guard.RecentPush {
+				return refuse("pushed " + since.Round(time.Second).String() + " ago")
+			}
+		}
+	}
+	switch {
+	case t.AllowDestructive, slices.Contains(guard.Allow, op):
+		return nil
+	case guard.Confirm != nil:
+		if guard.Confirm(ctx, op, target) {
+			return nil
+		}
+		return refuse("not confirmed")
+	}
+	return refuse("not allowed, set Property.AllowDestructive, Guard.Allow or Guard.Confirm")
+}
+
+// Return last push time of repository.
+//
+// Github: pushed_at, Gitlab: last_activity_at, Gitea: updated_at
+func (t *Base) pushedAt(ctx context.Context) (time.Time, error) {
+	p := *t.Property
+	p.DryRun = false
+	p.Guard = nil
+	p.Info = nil
+	b := New(&p).EndpointRepos().SetGet().DoContext(ctx)
+	if e := b.Err(); e != nil {
+		return time.Time{}, e
+	}
+	var repo struct {
+		LastActivityAt time.Time `json:"last_activity_at"`
+		PushedAt       time.Time `json:"pushed_at"`
+		UpdatedAt      time.Time `json:"updated_at"`
+	}
+	if e := json.Unmarshal(*b.Res.Body, &repo); e != nil {
+		return time.Time{}, e
+	}
+	for _, pushed := range []time.Time{repo.PushedAt, repo.LastActivityAt, repo.UpdatedAt} {
+		if !pushed.IsZero() {
+			return pushed, nil
+		}
+	}
+	return time.Time{}, errors.New("no push time in repository")
+}
@@ -0,0 +1,119 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Request event passed to hooks, one per attempt.
+//
+// Headers and bodies are redacted by RedactHeader and RedactBody.
+type Event struct {
+	Attempt  int    // 1 for first attempt
+	Endpoint string // Api.Req.Endpoint, eg. "repos/OWNER/REPO/topics"
+	Method   string
+	Name     string // Property.Name
+	Url      string
+	Vendor   vendor.Vendor
+
+	Header http.Header
+	Body   []byte
+
+	// Set after response
+	Duration   time.Duration
+	Err        error // transport error, or *Error of failed response
+	ResBody    []byte
+	ResHeader  http.Header
+	StatusCode int // 0 if there is no response
+	Time       time.Time
+}
+
+// Request hooks, nil hook is skipped.
+//
+// Before can return a derived context, eg. with a tracing span, which is used for the http request and
+// passed to After and OnError. After is called on every response, OnError on transport error or failed response.
+type Hooks struct {
+	Before  func(ctx context.Context, event *Event) context.Context
+	After   func(ctx context.Context, event *Event)
+	OnError func(ctx context.Context, event *Event)
+}
+
+// Return new event of current request and call Before hooks
+func (t *Base) hookBefore(ctx context.Context, attempt int, header http.Header) (context.Context, *Event) {
+	if len(t.Hooks) == 0 {
+		return ctx, nil
+	}
+	event := &Event{
+		Attempt:  attempt,
+		Body:     RedactBody([]byte(t.Req.Data)),
+		Endpoint: t.Req.Endpoint,
+		Header:   RedactHeader(header),
+		Method:   t.Method,
+		Name:     t.Property.Name,
+		Time:     time.Now(),
+		Url:      t.Res.Url.String(),
+		Vendor:   t.Vendor,
+	}
+	for _, hooks := range t.Hooks {
+		if hooks.Before != nil {
+			if c := hooks.Before(ctx, event); c != nil {
+				ctx = c
+			}
+		}
+	}
+	return ctx, event
+}
+
+// Fill event with response and call After, OnError hooks
+func (t *Base) hookAfter(ctx context.Context, event *Event, err error) {
+	if event == nil {
+		return
+	}
+	event.Duration = time.Since(event.Time)
+	event.StatusCode = t.StatusCode()
+	if t.Res.Header != nil {
+		event.ResHeader = RedactHeader(*t.Res.Header)
+	}
+	if t.Res.Body != nil {
+		event.ResBody = RedactBody(*t.Res.Body)
+	}
+	failed := err != nil || event.StatusCode >= http.StatusBadRequest
+	if failed {
+		event.Err = t.newError(err)
+	}
+	for _, hooks := range t.Hooks {
+		if event.StatusCode != 0 && hooks.After != nil {
+			hooks.After(ctx, event)
+		}
+		if failed && hooks.OnError != nil {
+			hooks.OnError(ctx, event)
+		}
+	}
+}
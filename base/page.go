@@ -0,0 +1,138 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"iter"
+	"net/url"
+	"regexp"
+	"strconv"
+)
+
+// Link header entry with rel="next"
+var linkNext = regexp.MustCompile(`<([^>]*)>\s*;[^,]*rel="?next"?`)
+
+// Return page number from Req.UrlVal, default 1
+func (t *Base) Page() int {
+	if t.Req.UrlVal != nil {
+		if page, e := strconv.Atoi(t.Req.UrlVal.Get("page")); e == nil {
+			return page
+		}
+	}
+	return 1
+}
+
+// Set page number in Req.UrlVal
+func (t *Base) SetPage(page int) *Base {
+	if t.Req.UrlVal == nil {
+		t.Req.UrlValInit()
+	}
+	t.Req.UrlVal.Set("page", strconv.Itoa(page))
+	return t
+}
+
+// Return per page number from Req.UrlVal, "per_page"(github, gitlab) or "limit"(gitea), 0 if not set
+func (t *Base) PerPage() int {
+	if t.Req.UrlVal != nil {
+		for _, key := range []string{"per_page", "limit"} {
+			if perPage, e := strconv.Atoi(t.Req.UrlVal.Get(key)); e == nil {
+				return perPage
+			}
+		}
+	}
+	return 0
+}
+
+// Return next page number, decided by following in order:
+//
+//   - response header `Link` with rel="next" (github, gitea, gitlab)
+//   - response header `X-Total-Count` (gitea, gitlab), against seen, number of items up to current page
+//   - count, number of items in current page, equal to PerPage()
+func (t *Base) PageNext(seen, count int) (page int, ok bool) {
+	if t.Res.Header == nil {
+		return 0, false
+	}
+	if link := t.Res.Header.Get("Link"); link != "" {
+		match := linkNext.FindStringSubmatch(link)
+		if match == nil {
+			return 0, false
+		}
+		if u, e := url.Parse(match[1]); e == nil {
+			if page, e = strconv.Atoi(u.Query().Get("page")); e == nil {
+				return page, true
+			}
+		}
+		// rel="next" without usable page parameter
+		return t.Page() + 1, true
+	}
+	if total, e := strconv.Atoi(t.Res.Header.Get("X-Total-Count")); e == nil {
+		return t.Page() + 1, seen < total && count > 0
+	}
+	perPage := t.PerPage()
+	return t.Page() + 1, perPage > 0 && count >= perPage
+}
+
+// Iterate items of list, page by page, starting from current Page().
+//
+// list is the response Info of t, it is decoded on every page.
+// Iteration stops after last page, or on first failed request, which is yielded as error.
+func Iter[S ~[]E, E any](ctx context.Context, t *Base, list *S) iter.Seq2[E, error] {
+	return func(yield func(E, error) bool) {
+		var (
+			seen  int
+			start = t.Page()
+		)
+		t.SetGet()
+		for {
+			// New slice per page, items yielded earlier must not be decoded into
+			*list = nil
+			if !t.DoContext(ctx).Ok() {
+				var zero E
+				yield(zero, t.Err())
+				return
+			}
+			if seen == 0 {
+				// Items before starting page, by per page, or by page size of server if lower
+				size := t.PerPage()
+				if size == 0 || len(*list) < size {
+					size = len(*list)
+				}
+				seen = (start - 1) * size
+			}
+			seen += len(*list)
+			for _, item := range *list {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			page, ok := t.PageNext(seen, len(*list))
+			if !ok {
+				return
+			}
+			t.SetPage(page)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Planned request of dry run
+type Step struct {
+	Method   string      `json:"method"`
+	Url      string      `json:"url"`
+	Endpoint string      `json:"endpoint"`
+	Header   http.Header `json:"header,omitempty"` // redacted
+	Body     string      `json:"body,omitempty"`   // redacted
+	Note     string      `json:"note,omitempty"`   // condition of step, eg. "if previous step returns 404"
+
+	Destructive Operation `json:"destructive,omitempty"` // guard is not checked in dry run
+}
+
+// Requests planned by dry run, in order
+type Plan struct {
+	Steps []Step `json:"steps"`
+}
+
+func (t *Plan) StringP() *string {
+	var b strings.Builder
+	for _, step := range t.Steps {
+		b.WriteString(step.Method + " " + step.Url)
+		if step.Destructive != "" {
+			b.WriteString(" [" + string(step.Destructive) + "]")
+		}
+		if step.Note != "" {
+			b.WriteString(" (" + step.Note + ")")
+		}
+		b.WriteString("\n")
+		if step.Body != "" {
+			b.WriteString(step.Body + "\n")
+		}
+	}
+	str := b.String()
+	return &str
+}
+
+func (t *Plan) String() string {
+	return *t.StringP()
+}
+
+// Return plan of last Do() in dry run, nil if not in dry run
+func (t *Base) Plan() *Plan {
+	return t.plan
+}
+
+// Prepend steps of plan to plan of last Do(), eg. steps of prerequisite requests
+func (t *Base) PlanPrepend(plan *Plan) *Base {
+	if t.plan != nil && plan != nil {
+		t.plan.Steps = slices.Concat(plan.Steps, t.plan.Steps)
+		t.Res.Output = t.plan.StringP()
+	}
+	return t
+}
+
+// Plan current request instead of sending it
+func (t *Base) dryRun() *Base {
+	header := t.Req.Header.Clone()
+	if e := t.authHeader(header, Redacted); e != nil {
+		return t.SetErr(e)
+	}
+	t.plan = &Plan{Steps: []Step{{
+		Method:   t.Method,
+		Url:      t.Res.Url.String(),
+		Endpoint: t.Req.Endpoint,
+		Header:   RedactHeader(header),
+		Body:     string(RedactBody([]byte(t.Req.Data))),
+
+		Destructive: t.Destructive(),
+	}}}
+	t.Res.Output = t.plan.StringP()
+	return t
+}
@@ -24,15 +24,56 @@ THE SOFTWARE.
 
 package base
 
+import (
+	"net/http"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
 type Property struct {
 	Debug      bool   `json:"debug,omitempty"`
+	DryRun     bool   `json:"dry_run,omitempty"` // plan requests, see Base.Plan(), instead of sending
 	EntryPoint string `json:"entry_point,omitempty"`
 	Info       IInfo  `json:"info,omitempty"`
 	SkipVerify bool   `json:"skip_verify,omitempty"`
 
-	Name   string `json:"name,omitempty"`
-	Repo   string `json:"repo,omitempty"`
-	Token  string `json:"token,omitempty"`
-	User   string `json:"user,omitempty"`
-	Vendor string `json:"vendor,omitempty"`
+	Capability      *Capability      `json:"capability,omitempty"`
+	RateLimitPolicy *RateLimitPolicy `json:"rate_limit_policy,omitempty"`
+	RetryPolicy     *RetryPolicy     `json:"retry_policy,omitempty"` // DefaultRetryPolicy() if nil
+
+	// Github App authentication, used instead of Token if AppId is set
+	AppId             int64  `json:"app_id,omitempty"`
+	AppInstallationId int64  `json:"app_installation_id,omitempty"`
+	AppPrivateKey     string `json:"app_private_key,omitempty"` // PEM
+
+	// Token provider, used instead of Token if set
+	TokenSource TokenSource `json:"-"`
+
+	// Http client options. Tls options are not applied if Client.Transport or Transport is set.
+	CaFile     string            `json:"ca_file,omitempty"`   // PEM CA bundle, added to system pool
+	CertFile   string            `json:"cert_file,omitempty"` // PEM client certificate, mTLS
+	KeyFile    string            `json:"key_file,omitempty"`  // PEM client key, mTLS
+	Client     *http.Client      `json:"-"`                   // timeout, redirect, cookie jar, transport
+	Middleware []Middleware      `json:"-"`                   // applied on transport, first is outermost
+	Transport  http.RoundTripper `json:"-"`                   // eg. recorder.Recorder
+
+	// Destructive operations, refused unless allowed by AllowDestructive or Guard
+	AllowDestructive bool   `json:"allow_destructive,omitempty"`
+	Guard            *Guard `json:"guard,omitempty"`
+
+	// Request hooks, for logging, metrics and tracing
+	Hooks []Hooks `json:"-"`
+
+	// Authorization
+	AuthScheme AuthScheme `json:"auth_scheme,omitempty"`
+	Login      string     `json:"login,omitempty"`    // basic auth, User is used if empty
+	Otp        string     `json:"otp,omitempty"`      // basic auth 2fa, github/gitea/forgejo
+	Password   string     `json:"password,omitempty"` // basic auth, token is used if empty
+	Sudo       string     `json:"sudo,omitempty"`     // admin impersonation, gitea/forgejo/gogs/gitlab
+
+	Name   string        `json:"name,omitempty"`
+	Repo   string        `json:"repo,omitempty"`
+	Token  string        `json:"token,omitempty"`
+	User   string        `json:"user,omitempty"`
+	Vendor vendor.Vendor `json:"vendor,omitempty"`
 }
@@ -0,0 +1,154 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default wait on rate limited response without reset information
+const RateLimitWaitDefault = time.Minute
+
+// Rate limit status, from response header
+type RateLimit struct {
+	Limit      int           `json:"limit"`
+	Remaining  int           `json:"remaining"`
+	Used       int           `json:"used,omitempty"`
+	Reset      time.Time     `json:"reset"`
+	Resource   string        `json:"resource,omitempty"`    // github only
+	RetryAfter time.Duration `json:"retry_after,omitempty"` // from header `Retry-After`
+}
+
+// Wait and retry policy on rate limited response(http 429, 403 with rate limit exhausted)
+type RateLimitPolicy struct {
+	Wait       bool          `json:"wait,omitempty"`        // wait and retry on rate limited response
+	MaxWait    time.Duration `json:"max_wait,omitempty"`    // max wait before a retry, 0 for no limit
+	MaxRetries int           `json:"max_retries,omitempty"` // max retries, 0 for 1 retry
+}
+
+// Wait needed before next request, 0 if not rate limited
+func (t *RateLimit) Wait(now time.Time) time.Duration {
+	if t.RetryAfter > 0 {
+		return t.RetryAfter
+	}
+	if t.Remaining == 0 && !t.Reset.IsZero() {
+		if wait := t.Reset.Sub(now); wait > 0 {
+			return wait + time.Second
+		}
+	}
+	return 0
+}
+
+// Parse rate limit response header, nil if not found.
+//
+//   - `X-RateLimit-*` (github, gitea), reset is unix time
+//   - `RateLimit-*` (gitlab), reset is unix time, or seconds if value is small
+//   - `Retry-After`, seconds or http date
+func parseRateLimit(header http.Header) *RateLimit {
+	var (
+		found     bool
+		rateLimit RateLimit
+	)
+	for _, prefix := range []string{"X-RateLimit-", "RateLimit-"} {
+		if header.Get(prefix+"Limit") == "" {
+			continue
+		}
+		found = true
+		rateLimit.Limit, _ = strconv.Atoi(header.Get(prefix + "Limit"))
+		rateLimit.Remaining, _ = strconv.Atoi(header.Get(prefix + "Remaining"))
+		rateLimit.Used, _ = strconv.Atoi(header.Get(prefix + "Used"))
+		rateLimit.Resource = header.Get(prefix + "Resource")
+		if reset, e := strconv.ParseInt(header.Get(prefix+"Reset"), 10, 64); e == nil {
+			if reset < 1_000_000_000 {
+				rateLimit.Reset = time.Now().Add(time.Duration(reset) * time.Second)
+			} else {
+				rateLimit.Reset = time.Unix(reset, 0)
+			}
+		}
+		break
+	}
+	if retryAfter := header.Get("Retry-After"); retryAfter != "" {
+		found = true
+		if seconds, e := strconv.Atoi(retryAfter); e == nil {
+			rateLimit.RetryAfter = time.Duration(seconds) * time.Second
+		} else if date, e := http.ParseTime(retryAfter); e == nil {
+			rateLimit.RetryAfter = time.Until(date)
+		}
+	}
+	if !found {
+		return nil
+	}
+	return &rateLimit
+}
+
+// Return rate limit status of last response, nil if not available
+func (t *Base) RateLimit() *RateLimit {
+	return t.rateLimit
+}
+
+// Check if last response is rate limited
+func (t *Base) RateLimited() bool {
+	switch t.StatusCode() {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusForbidden:
+		// github primary(remaining 0) and secondary(retry-after) rate limit
+		return t.rateLimit != nil && (t.rateLimit.Remaining == 0 && t.rateLimit.Limit > 0 || t.rateLimit.RetryAfter > 0)
+	}
+	return false
+}
+
+// Return wait before retry number n on rate limited response, and if request should be retried
+func (t *Base) rateLimitDelay(n int) (time.Duration, bool) {
+	policy := t.RateLimitPolicy
+	if policy == nil || !policy.Wait || n > max(policy.MaxRetries, 1) {
+		return 0, false
+	}
+	wait := RateLimitWaitDefault
+	if t.rateLimit != nil {
+		if w := t.rateLimit.Wait(time.Now()); w > 0 {
+			wait = w
+		}
+	}
+	if policy.MaxWait > 0 && wait > policy.MaxWait {
+		return 0, false
+	}
+	return wait, true
+}
+
+// Wait for d, or return ctx error if ctx is done first
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,110 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Replacement of redacted values
+const Redacted = "REDACTED"
+
+// Headers carrying credentials, canonical form
+var RedactHeaders = []string{
+	"Authorization",
+	"Cookie",
+	"Private-Token",
+	"Set-Cookie",
+	"Sudo",
+	"X-Forgejo-Otp",
+	"X-Gitea-Otp",
+	"X-Github-Otp",
+}
+
+// Json body fields carrying secrets: sealed secret, gitea secret, gitlab variable, tokens
+var RedactFields = []string{
+	"access_token",
+	"data",
+	"encrypted_value",
+	"refresh_token",
+	"token",
+	"value",
+}
+
+// Return copy of header with credentials redacted.
+// Scheme of Authorization is kept, eg. "token REDACTED".
+func RedactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	for _, k := range RedactHeaders {
+		if _, ok := header[k]; ok {
+			scheme, _, found := strings.Cut(header.Get(k), " ")
+			if k == "Authorization" && found {
+				header.Set(k, scheme+" "+Redacted)
+			} else {
+				header.Set(k, Redacted)
+			}
+		}
+	}
+	return header
+}
+
+// Return json body with secret fields redacted, at any depth.
+// Non-json body is returned as is.
+func RedactBody(body []byte) []byte {
+	var v any
+	if len(body) == 0 || json.Unmarshal(body, &v) != nil {
+		return body
+	}
+	redacted, e := json.Marshal(redact(v))
+	if e != nil {
+		return body
+	}
+	return redacted
+}
+
+func redact(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for k, field := range v {
+			if s, ok := field.(string); ok && s != "" && redactField(k) {
+				v[k] = Redacted
+			} else {
+				v[k] = redact(field)
+			}
+		}
+	case []any:
+		for i := range v {
+			v[i] = redact(v[i])
+		}
+	}
+	return v
+}
+
+func redactField(k string) bool {
+	return slices.Contains(RedactFields, strings.ToLower(k))
+}
@@ -0,0 +1,239 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"bufio"
+	"errors"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Returned by GitRemote() if remote is not found
+var ErrRemote = errors.New("git remote not found")
+
+// Vendor and api entry point of well known hosts
+var remoteHosts = map[string]struct {
+	vendor     vendor.Vendor
+	entryPoint string
+}{
+	"github.com":   {vendor.Github, "https://api.github.com"},
+	"gitlab.com":   {vendor.Gitlab, "https://gitlab.com" + ApiPathGitlab},
+	"gitea.com":    {vendor.Gitea, "https://gitea.com" + ApiPathGitea},
+	"codeberg.org": {vendor.Forgejo, "https://codeberg.org" + ApiPathGitea},
+}
+
+// Fill property User, Repo, EntryPoint and Vendor from url of git remote in dir.
+//
+// dir can be any directory inside a repository, worktree or submodule.
+// `url.<base>.insteadOf` in git config is applied to remote url.
+//
+// Vendor and EntryPoint are set for well known hosts(github.com, gitlab.com,
+// gitea.com, codeberg.org). For other hosts, Vendor is not changed and
+// EntryPoint is set with api path of Vendor. Use Detect() to confirm them.
+func GitRemote(property *Property, dir, remote string) error {
+	gitDir, e := findGitDir(dir)
+	if e != nil {
+		return e
+	}
+	config, e := readGitConfig(gitConfigFile(gitDir))
+	if e != nil {
+		return e
+	}
+	rawUrl := config[`remote "`+remote+`".url`]
+	if len(rawUrl) == 0 {
+		return errors.Join(ErrRemote, errors.New(remote))
+	}
+	remoteUrl := gitInsteadOf(config, rawUrl[0])
+	u, e := parseGitUrl(remoteUrl)
+	if e != nil {
+		return e
+	}
+	p := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	user, repo := path.Split(p)
+	if user == "" || repo == "" {
+		return errors.New("remote url has no owner and repository: " + remoteUrl)
+	}
+	property.User = strings.TrimSuffix(user, "/")
+	property.Repo = repo
+
+	if host, ok := remoteHosts[u.Hostname()]; ok {
+		property.Vendor = host.vendor
+		property.EntryPoint = host.entryPoint
+		return nil
+	}
+	entryPoint := url.URL{Scheme: "https", Host: u.Hostname()}
+	if u.Scheme == "http" || u.Scheme == "https" {
+		// Keep port of http remote only
+		entryPoint.Scheme = u.Scheme
+		entryPoint.Host = u.Host
+	}
+	switch property.Vendor {
+	case vendor.Github:
+		entryPoint.Path = ApiPathGithub
+	case vendor.Gitlab:
+		entryPoint.Path = ApiPathGitlab
+	default:
+		entryPoint.Path = ApiPathGitea
+	}
+	property.EntryPoint = entryPoint.String()
+	return nil
+}
+
+// Find git directory of dir, or its parents.
+// ".git" file("gitdir: <path>") of worktree and submodule is followed.
+func findGitDir(dir string) (string, error) {
+	dir, e := filepath.Abs(dir)
+	if e != nil {
+		return "", e
+	}
+	for {
+		dotGit := filepath.Join(dir, ".git")
+		info, e := os.Stat(dotGit)
+		if e == nil {
+			if info.IsDir() {
+				return dotGit, nil
+			}
+			data, e := os.ReadFile(dotGit)
+			if e != nil {
+				return "", e
+			}
+			gitDir, found := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+			if !found {
+				return "", errors.New("invalid .git file: " + dotGit)
+			}
+			gitDir = filepath.FromSlash(strings.TrimSpace(gitDir))
+			if !filepath.IsAbs(gitDir) {
+				gitDir = filepath.Join(dir, gitDir)
+			}
+			return gitDir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not a git repository")
+		}
+		dir = parent
+	}
+}
+
+// Return config file of gitDir, worktree use config of main repository("commondir")
+func gitConfigFile(gitDir string) string {
+	if data, e := os.ReadFile(filepath.Join(gitDir, "commondir")); e == nil {
+		commonDir := filepath.FromSlash(strings.TrimSpace(string(data)))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		return filepath.Join(commonDir, "config")
+	}
+	return filepath.Join(gitDir, "config")
+}
+
+// Read git config file into map of `section "subsection".key` -> values.
+// Section and key are lower case, subsection is case-sensitive.
+func readGitConfig(file string) (map[string][]string, error) {
+	f, e := os.Open(file)
+	if e != nil {
+		return nil, e
+	}
+	defer f.Close()
+
+	var (
+		config  = make(map[string][]string)
+		scanner = bufio.NewScanner(f)
+		section string
+	)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || line[0] == ';' {
+			continue
+		}
+		if line[0] == '[' {
+			end := strings.LastIndex(line, "]")
+			if end < 0 {
+				continue
+			}
+			name, sub, found := strings.Cut(line[1:end], " ")
+			section = strings.ToLower(name)
+			if found {
+				section += " " + strings.TrimSpace(sub)
+			}
+			line = strings.TrimSpace(line[end+1:])
+			if line == "" {
+				continue
+			}
+		}
+		key, value, _ := strings.Cut(line, "=")
+		key = strings.ToLower(strings.TrimSpace(key))
+		value = strings.Trim(strings.TrimSpace(value), `"`)
+		config[section+"."+key] = append(config[section+"."+key], value)
+	}
+	return config, scanner.Err()
+}
+
+// Apply longest matching `url.<base>.insteadOf` to remoteUrl
+func gitInsteadOf(config map[string][]string, remoteUrl string) string {
+	var base, prefix string
+	for key, values := range config {
+		name, found := strings.CutPrefix(key, `url "`)
+		if !found || !strings.HasSuffix(name, `".insteadof`) {
+			continue
+		}
+		for _, value := range values {
+			if strings.HasPrefix(remoteUrl, value) && len(value) > len(prefix) {
+				base = strings.TrimSuffix(name, `".insteadof`)
+				prefix = value
+			}
+		}
+	}
+	if prefix == "" {
+		return remoteUrl
+	}
+	return base + strings.TrimPrefix(remoteUrl, prefix)
+}
+
+// Parse git remote url, scp-like ssh url "[user@]host:path" is converted to "ssh://"
+func parseGitUrl(remoteUrl string) (*url.URL, error) {
+	if !strings.Contains(remoteUrl, "://") {
+		colon := strings.Index(remoteUrl, ":")
+		slash := strings.Index(remoteUrl, "/")
+		if colon <= 0 || (slash >= 0 && slash < colon) {
+			return nil, errors.New("unsupported remote url: " + remoteUrl)
+		}
+		remoteUrl = "ssh://" + remoteUrl[:colon] + "/" + strings.TrimPrefix(remoteUrl[colon+1:], "/")
+	}
+	u, e := url.Parse(remoteUrl)
+	if e != nil {
+		return nil, e
+	}
+	if u.Host == "" {
+		return nil, errors.New("unsupported remote url: " + remoteUrl)
+	}
+	return u, nil
+}
@@ -0,0 +1,161 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"slices"
+	"time"
+)
+
+// RetryPolicy defaults, used for zero value fields
+var (
+	RetryBackoffDefault     = 500 * time.Millisecond
+	RetryMaxBackoffDefault  = 30 * time.Second
+	RetryMethodsDefault     = []string{http.MethodGet, http.MethodHead, http.MethodPut, http.MethodDelete}
+	RetryStatusCodesDefault = []int{http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout}
+)
+
+// Retry policy on transient failure: transport error or retryable status code.
+//
+// DefaultRetryPolicy() is used if Property.RetryPolicy is nil, MaxAttempts 1 to disable.
+// Only idempotent methods are retried by default. Non-idempotent methods,
+// POST and PATCH, are retried only if listed in Methods explicitly.
+type RetryPolicy struct {
+	MaxAttempts int           `json:"max_attempts,omitempty"` // total attempts including the first one, <= 1 for no retry
+	Backoff     time.Duration `json:"backoff,omitempty"`      // wait before first retry, doubled on each retry
+	MaxBackoff  time.Duration `json:"max_backoff,omitempty"`  // max wait before a retry
+	Jitter      float64       `json:"jitter,omitempty"`       // wait is randomized by +/- Jitter(0 to 1) fraction
+	Methods     []string      `json:"methods,omitempty"`      // retryable http methods
+	StatusCodes []int         `json:"status_codes,omitempty"` // retryable http status codes
+}
+
+// Return a RetryPolicy with 3 attempts and default settings
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		Backoff:     RetryBackoffDefault,
+		MaxBackoff:  RetryMaxBackoffDefault,
+		Jitter:      0.2,
+		Methods:     RetryMethodsDefault,
+		StatusCodes: RetryStatusCodesDefault,
+	}
+}
+
+// Check if failed attempt is retryable.
+// err is transport error, statusCode is 0 if there is no response.
+// Tls handshake and certificate errors are not retried.
+func (t *RetryPolicy) Retryable(method string, statusCode int, err error) bool {
+	methods := t.Methods
+	if methods == nil {
+		methods = RetryMethodsDefault
+	}
+	if !slices.Contains(methods, method) {
+		return false
+	}
+	if err != nil {
+		return statusCode == 0 && !tlsError(err)
+	}
+	statusCodes := t.StatusCodes
+	if statusCodes == nil {
+		statusCodes = RetryStatusCodesDefault
+	}
+	return slices.Contains(statusCodes, statusCode)
+}
+
+// Check if err is a tls handshake or certificate error, which is not transient
+func tlsError(err error) bool {
+	var (
+		alert     *net.OpError // tls alert from server, eg. certificate required
+		authority x509.UnknownAuthorityError
+		hostname  x509.HostnameError
+		invalid   x509.CertificateInvalidError
+		verify    *tls.CertificateVerificationError
+	)
+	return errors.As(err, &alert) && alert.Op == "remote error" ||
+		errors.As(err, &authority) || errors.As(err, &hostname) || errors.As(err, &invalid) || errors.As(err, &verify)
+}
+
+// Return wait before retry number n(1 for first retry)
+func (t *RetryPolicy) Delay(n int) time.Duration {
+	var (
+		backoff    = t.Backoff
+		maxBackoff = t.MaxBackoff
+	)
+	if backoff <= 0 {
+		backoff = RetryBackoffDefault
+	}
+	if maxBackoff <= 0 {
+		maxBackoff = RetryMaxBackoffDefault
+	}
+	delay := backoff
+	for i := 1; i < n && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+	delay = min(delay, maxBackoff)
+	if jitter := min(max(t.Jitter, 0), 1); jitter > 0 {
+		delay += time.Duration((rand.Float64()*2 - 1) * jitter * float64(delay))
+	}
+	return delay
+}
+
+// Retry counters of a request
+type retryState struct {
+	attempt     int // failed attempts
+	rateLimited int // retries on rate limited response
+}
+
+// Return wait before next attempt, and if request should be retried.
+//
+// Rate limited response is handled by Property.RateLimitPolicy,
+// other failures by Property.RetryPolicy.
+func (t *Base) retryDelay(ctx context.Context, state *retryState, err error) (time.Duration, bool) {
+	state.attempt++
+	if ctx.Err() != nil || errors.Is(err, ErrAuth) || errors.Is(err, errClient) {
+		return 0, false
+	}
+	if err == nil && t.RateLimited() {
+		state.rateLimited++
+		return t.rateLimitDelay(state.rateLimited)
+	}
+	policy := t.RetryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy()
+	}
+	if state.attempt >= policy.MaxAttempts || !policy.Retryable(t.Method, t.StatusCode(), err) {
+		return 0, false
+	}
+	delay := policy.Delay(state.attempt - state.rateLimited)
+	if t.rateLimit != nil && t.rateLimit.RetryAfter > delay {
+		delay = t.rateLimit.RetryAfter
+	}
+	return delay, true
+}
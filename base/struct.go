@@ -25,9 +25,18 @@ THE SOFTWARE.
 package base
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"path"
+	"slices"
 
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+	"github.com/J-Siu/go-helper/v2/ezlog"
 	"github.com/J-Siu/go-restapi"
 )
 
@@ -35,6 +44,12 @@ import (
 type Base struct {
 	*Property
 	*restapi.Api
+	destructive Operation
+	err         *Error
+	log         *ezlog.EzLog
+	plan        *Plan
+	rateLimit   *RateLimit
+	unsupported error
 }
 
 // Setup a *GitApi
@@ -47,38 +62,189 @@ func (t *Base) New(property *Property) *Base {
 		SkipVerify: t.SkipVerify,
 	}
 	t.Api = new(restapi.Api).New(&apiProperty)
-	t.HeaderGithub()
+	t.log = ezlog.New()
+	if t.Debug {
+		t.log.SetLogLevel(ezlog.DEBUG)
+	}
+	if t.IsVendor(vendor.Gitlab) {
+		t.HeaderGitlab()
+	} else {
+		t.HeaderGithub()
+	}
 	return t
 }
 
+// Execute request with context.Background()
 func (t *Base) Do() *Base {
-	t.Api.Do()
+	return t.DoContext(context.Background())
+}
+
+// Execute request using info in Api.Req, then put response info in Api.Res.
+//
+// ctx is attached to the http request, cancellation and deadline are applied
+// to connection, request, reading of response body and waiting before retry.
+func (t *Base) DoContext(ctx context.Context) *Base {
+	var err error
+	// Clear result of previous request
+	*t.Res = restapi.Res{}
+	t.err = nil
+	t.plan = nil
+	t.rateLimit = nil
+	if t.unsupported != nil {
+		return t.SetErr(t.unsupported)
+	}
+	// Prepare Api Data
+	if t.Method != http.MethodGet && t.Api.Info != nil {
+		j, _ := json.Marshal(&t.Api.Info)
+		t.Req.Data = string(j)
+	}
+	// Prepare url
+	t.Res.Url, err = url.Parse(t.Req.EntryPoint)
+	if err == nil {
+		// Endpoint is escaped path, eg. gitlab project id "OWNER%2FREPO"
+		t.Res.Url = t.Res.Url.JoinPath(t.Req.Endpoint)
+		if t.Req.UrlVal != nil {
+			t.Res.Url.RawQuery = t.Req.UrlVal.Encode()
+		}
+		if t.DryRun {
+			return t.dryRun()
+		}
+		if err = t.guard(ctx); err != nil {
+			return t.SetErr(err)
+		}
+		// Request
+		var state retryState
+		for {
+			err = t.send(ctx, state.attempt+1)
+			delay, retry := t.retryDelay(ctx, &state, err)
+			if !retry {
+				break
+			}
+			t.log.Debug().N("api").N("retry").M(state.attempt).M(delay).Out()
+			if err = sleep(ctx, delay); err != nil {
+				break
+			}
+		}
+	}
+	if err != nil {
+		t.Res.Err = err.Error()
+	}
+
+	// Unmarshal
+	switch {
+	case t.Res.Err != "":
+		t.ProcessError()
+	case t.Res.Ok():
+		t.processOutput()
+	default:
+		t.ProcessOutput()
+	}
+	if !t.Res.Ok() {
+		t.err = t.newError(err)
+	}
+
+	t.log.Debug().N("api").Lm(t.Api).Ln("api.Res.Body (decoded)").M(t.Res.Body).Out()
 	return t
 }
 
+// Decode successful response into Api.Info.
+//
+// Unlike Api.ProcessOutput(), "message" in body is not an error, eg. github merge-upstream.
+func (t *Base) processOutput() {
+	if t.Api.Info == nil {
+		output := string(*t.Res.Body)
+		t.Res.Output = &output
+		return
+	}
+	if json.Unmarshal(*t.Res.Body, t.Api.Info) == nil {
+		t.Res.Output = t.Api.Info.StringP()
+	}
+}
+
+// Send request once, response is put in Api.Res.
+//
+// attempt is passed to hooks.
+func (t *Base) send(ctx context.Context, attempt int) (err error) {
+	t.Res.Body = nil
+	t.Res.Header = nil
+	t.Res.Status = ""
+	header := t.Req.Header.Clone()
+	authErr := t.authorize(ctx, header)
+	ctx, event := t.hookBefore(ctx, attempt, header)
+	defer func() { t.hookAfter(ctx, event, err) }()
+	if authErr != nil {
+		return authErr
+	}
+	req, err := http.NewRequestWithContext(ctx, t.Method, t.Res.Url.String(), bytes.NewBufferString(t.Req.Data))
+	if err != nil {
+		return err
+	}
+	req.Header = header
+	client, err := t.httpClient()
+	if err != nil {
+		return fmt.Errorf("%w: %w", errClient, err)
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	t.Res.Body = &body
+	t.Res.Header = &res.Header
+	t.Res.Status = res.Status
+	if rateLimit := parseRateLimit(res.Header); rateLimit != nil {
+		t.rateLimit = rateLimit
+	}
+	return err
+}
+
 // Initialize endpoint /user/repos
+//
+// Gitlab: /projects
 func (t *Base) EndpointUserRepos() *Base {
-	t.Req.Endpoint = "/user/repos"
+	if t.IsVendor(vendor.Gitlab) {
+		t.Req.Endpoint = "/projects"
+	} else {
+		t.Req.Endpoint = "/user/repos"
+	}
 	return t
 }
 
 // Initialize endpoint /repos/OWNER/REPO
 //
-// Use current directory if GitApi.Repo is empty
+// Gitlab: /projects/OWNER%2FREPO
+//
+// Use GitRemote() to fill Property.User and Property.Repo from git remote of current directory
 func (t *Base) EndpointRepos() *Base {
-	t.Req.Endpoint = path.Join("repos", t.User, *t.Repo())
+	if t.IsVendor(vendor.Gitlab) {
+		t.Req.Endpoint = path.Join("projects", url.PathEscape(t.User+"/"+*t.Repo()))
+	} else {
+		t.Req.Endpoint = path.Join("repos", t.User, *t.Repo())
+	}
 	return t
 }
 
 // Initialize endpoint /repos/OWNER/REPO/topics
+//
+// Gitlab: /projects/OWNER%2FREPO, topics are part of project
 func (t *Base) EndpointReposTopics() *Base {
+	if t.IsVendor(vendor.Gitlab) {
+		return t.EndpointRepos()
+	}
 	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "topics")
 	return t
 }
 
 // Initialize endpoint /repos/OWNER/REPO/actions/secrets
+//
+// Gitlab: /projects/OWNER%2FREPO/variables
 func (t *Base) EndpointReposSecrets() *Base {
-	t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "actions", "secrets")
+	if t.IsVendor(vendor.Gitlab) {
+		t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "variables")
+	} else {
+		t.Req.Endpoint = path.Join(t.EndpointRepos().Req.Endpoint, "actions", "secrets")
+	}
 	return t
 }
 
@@ -109,6 +275,20 @@ func (t *Base) HeaderGithub() *Base {
 	return t
 }
 
+// Set gitlab header
+//
+// GitApi.Req.Token, if empty, PRIVATE-TOKEN header will not be set.
+func (t *Base) HeaderGitlab() *Base {
+	header := make(http.Header)
+	t.Req.Header = &header
+	t.Req.Header.Add("Accept", "application/json")
+	t.Req.Header.Add("Content-Type", "application/json")
+	if t.Token != "" {
+		t.Req.Header.Add("PRIVATE-TOKEN", t.Token)
+	}
+	return t
+}
+
 // Setup empty API header
 func (t *Base) HeaderInit() *Base {
 	header := make(http.Header)
@@ -116,6 +296,14 @@ func (t *Base) HeaderInit() *Base {
 	return t
 }
 
+// Set http method for editing repository: PATCH, gitlab PUT
+func (t *Base) SetEdit() *Base {
+	if t.IsVendor(vendor.Gitlab) {
+		return t.SetPut()
+	}
+	return t.SetPatch()
+}
+
 func (t *Base) SetGet() *Base {
 	t.Api.SetGet()
 	return t
@@ -141,8 +329,46 @@ func (t *Base) SetPut() *Base {
 	return t
 }
 
-func (t *Base) Err() *string    { return t.Api.Err() }
+// Set request body and response destination, nil for none
+func (t *Base) SetInfo(v IInfo) *Base {
+	t.Property.Info = v
+	t.Api.Info = v
+	return t
+}
+
+// Check if Property.Vendor is one of vendors
+func (t *Base) IsVendor(vendors ...vendor.Vendor) bool {
+	return slices.Contains(vendors, t.Vendor)
+}
+
+// Return ErrUnsupported error if operation is marked unsupported, else nil
+func (t *Base) Unsupported() error {
+	return t.unsupported
+}
+
+// Mark operation as unsupported by vendor, Do() will fail with ErrUnsupported.
+// Empty operation clears the mark.
+func (t *Base) SetUnsupported(operation string) *Base {
+	if operation == "" {
+		t.unsupported = nil
+	} else {
+		t.unsupported = fmt.Errorf("%w: %s on %s", ErrUnsupported, operation, t.Vendor)
+	}
+	return t
+}
+
 func (t *Base) Name() string    { return t.Property.Name }
-func (t *Base) Ok() bool        { return t.Api.Ok() }
+func (t *Base) Ok() bool        { return (t.plan != nil && t.err == nil) || t.Api.Ok() }
 func (t *Base) Output() *string { return t.Api.Output() }
 func (t *Base) Repo() *string   { return &t.Property.Repo }
+
+// Return error of last request, nil if there is no error.
+//
+// Non-nil error is an *Error, which can be matched with errors.Is() against
+// sentinel errors, eg. ErrNotFound, ErrValidation, ErrTransport.
+func (t *Base) Err() error {
+	if t.err == nil {
+		return nil
+	}
+	return t.err
+}
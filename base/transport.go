@@ -0,0 +1,102 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package base
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Http middleware, wrapping next round tripper
+type Middleware func(next http.RoundTripper) http.RoundTripper
+
+// Transport cache of tls options, for connection reuse
+var transports sync.Map
+
+// Http client configuration error, eg. bad tls files, not retried
+var errClient = errors.New("http client")
+
+// Return http client of request.
+//
+// Round tripper in order: Property.Transport, Property.Client.Transport, transport of tls options
+// (SkipVerify, CaFile, CertFile, KeyFile) with proxy from environment.
+// Property.Middleware is applied on top, first middleware is outermost.
+func (t *Base) httpClient() (*http.Client, error) {
+	client := new(http.Client)
+	if t.Client != nil {
+		*client = *t.Client
+	}
+	if t.Transport != nil {
+		client.Transport = t.Transport
+	}
+	if client.Transport == nil {
+		transport, e := t.tlsTransport()
+		if e != nil {
+			return nil, e
+		}
+		client.Transport = transport
+	}
+	for i := len(t.Middleware) - 1; i >= 0; i-- {
+		client.Transport = t.Middleware[i](client.Transport)
+	}
+	return client, nil
+}
+
+// Return cached transport of tls options
+func (t *Base) tlsTransport() (http.RoundTripper, error) {
+	cacheKey := fmt.Sprintf("%t|%s|%s|%s", t.SkipVerify, t.CaFile, t.CertFile, t.KeyFile)
+	if transport, ok := transports.Load(cacheKey); ok {
+		return transport.(http.RoundTripper), nil
+	}
+	config := &tls.Config{InsecureSkipVerify: t.SkipVerify}
+	if t.CaFile != "" {
+		pem, e := os.ReadFile(t.CaFile)
+		if e != nil {
+			return nil, e
+		}
+		if config.RootCAs, e = x509.SystemCertPool(); e != nil {
+			config.RootCAs = x509.NewCertPool()
+		}
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate found in " + t.CaFile)
+		}
+	}
+	if t.CertFile != "" || t.KeyFile != "" {
+		cert, e := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if e != nil {
+			return nil, e
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = config
+	actual, _ := transports.LoadOrStore(cacheKey, transport)
+	return actual.(http.RoundTripper), nil
+}
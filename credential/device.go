@@ -0,0 +1,233 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package credential
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Device flow errors
+var (
+	ErrDeviceDenied  = errors.New("device authorization denied")
+	ErrDeviceExpired = errors.New("device code expired")
+)
+
+// Device flow grant type, RFC 8628
+const DeviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// Device authorization response, shown to user by Device.Prompt
+type DeviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+	UserCode                string `json:"user_code"`
+	VerificationUri         string `json:"verification_uri"`
+	VerificationUriComplete string `json:"verification_uri_complete,omitempty"`
+}
+
+// Token response of device flow polling
+type DeviceToken struct {
+	AccessToken  string `json:"access_token"`
+	ExpiresIn    int    `json:"expires_in,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+	TokenType    string `json:"token_type,omitempty"`
+
+	Error            string `json:"error,omitempty"`
+	ErrorDescription string `json:"error_description,omitempty"`
+	Interval         int    `json:"interval,omitempty"` // github: new interval on slow_down
+}
+
+// OAuth2 device authorization flow login.
+//
+// Token is cached after first success. Prompt is called once with the user code to display.
+type Device struct {
+	ClientId      string
+	DeviceCodeUrl string
+	Scope         []string
+	TokenUrl      string
+
+	Client   *http.Client                 // default http.DefaultClient
+	Interval time.Duration                // polling interval, overrides server interval and slow_down step if set
+	Prompt   func(code *DeviceCode) error // required
+
+	mutex sync.Mutex
+	token *DeviceToken
+}
+
+// Device flow of github.com OAuth App or Github App
+func DeviceGithub(clientId string, scope ...string) *Device {
+	return &Device{
+		ClientId:      clientId,
+		DeviceCodeUrl: "https://github.com/login/device/code",
+		Scope:         scope,
+		TokenUrl:      "https://github.com/login/oauth/access_token",
+	}
+}
+
+// Device flow of Gitea OAuth2 application.
+//
+// server: eg. https://gitea.com. Url can be changed if server use different path.
+func DeviceGitea(server, clientId string, scope ...string) *Device {
+	server = strings.TrimSuffix(server, "/")
+	return &Device{
+		ClientId:      clientId,
+		DeviceCodeUrl: server + "/login/oauth/device/code",
+		Scope:         scope,
+		TokenUrl:      server + "/login/oauth/access_token",
+	}
+}
+
+func (t *Device) Token(ctx context.Context) (string, error) {
+	token, e := t.Login(ctx)
+	if e != nil {
+		return "", e
+	}
+	return token.AccessToken, nil
+}
+
+// Run device flow: request device code, call Prompt, poll for token
+func (t *Device) Login(ctx context.Context) (*DeviceToken, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != nil {
+		return t.token, nil
+	}
+	if t.Prompt == nil {
+		return nil, errors.New("device flow: Prompt not set")
+	}
+	code := new(DeviceCode)
+	if e := t.post(ctx, t.DeviceCodeUrl, url.Values{
+		"client_id": {t.ClientId},
+		"scope":     {strings.Join(t.Scope, " ")},
+	}, code); e != nil {
+		return nil, e
+	}
+	if code.DeviceCode == "" {
+		return nil, errors.New("device flow: empty device_code")
+	}
+	if e := t.Prompt(code); e != nil {
+		return nil, e
+	}
+	token, e := t.poll(ctx, code)
+	if e != nil {
+		return nil, e
+	}
+	t.token = token
+	return t.token, nil
+}
+
+// Poll token url until authorized, denied or expired
+func (t *Device) poll(ctx context.Context, code *DeviceCode) (*DeviceToken, error) {
+	step := 5 * time.Second
+	interval := time.Duration(max(code.Interval, 5)) * time.Second
+	if t.Interval > 0 {
+		step, interval = t.Interval, t.Interval
+	}
+	var deadline <-chan time.Time
+	if code.ExpiresIn > 0 {
+		timer := time.NewTimer(time.Duration(code.ExpiresIn) * time.Second)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	form := url.Values{
+		"client_id":   {t.ClientId},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {DeviceGrantType},
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-deadline:
+			return nil, ErrDeviceExpired
+		case <-time.After(interval):
+		}
+		token := new(DeviceToken)
+		if e := t.post(ctx, t.TokenUrl, form, token); e != nil && token.Error == "" {
+			return nil, e
+		}
+		switch token.Error {
+		case "":
+			if token.AccessToken == "" {
+				return nil, errors.New("device flow: empty access_token")
+			}
+			return token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += step
+			if token.Interval > 0 && t.Interval == 0 {
+				interval = time.Duration(token.Interval) * time.Second
+			}
+		case "expired_token":
+			return nil, ErrDeviceExpired
+		case "access_denied":
+			return nil, ErrDeviceDenied
+		default:
+			return nil, fmt.Errorf("device flow: %s %s", token.Error, token.ErrorDescription)
+		}
+	}
+}
+
+// Post form and decode json response into output.
+//
+// Output is decoded on error status also, as token errors are returned with 400.
+func (t *Device) post(ctx context.Context, endpoint string, form url.Values, output any) error {
+	req, e := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if e != nil {
+		return e
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	client := t.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, e := client.Do(req)
+	if e != nil {
+		return e
+	}
+	defer res.Body.Close()
+	body, e := io.ReadAll(res.Body)
+	if e != nil {
+		return e
+	}
+	if e = json.Unmarshal(body, output); e != nil {
+		return fmt.Errorf("device flow: %s %s: %w", res.Status, strings.TrimSpace(string(body)), e)
+	}
+	if res.StatusCode >= 300 {
+		return fmt.Errorf("device flow: %s", res.Status)
+	}
+	return nil
+}
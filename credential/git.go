@@ -0,0 +1,87 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package credential
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"net/url"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+)
+
+// Token from git credential helpers, using `git credential fill`.
+//
+// Token is cached after first success. Git is not allowed to prompt on terminal.
+type Git struct {
+	Url string // eg. https://github.com or https://github.com/OWNER/REPO.git
+
+	mutex sync.Mutex
+	token string
+}
+
+func (t *Git) Token(ctx context.Context) (string, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.token != "" {
+		return t.token, nil
+	}
+	u, e := url.Parse(t.Url)
+	if e != nil {
+		return "", e
+	}
+	var input strings.Builder
+	input.WriteString("protocol=" + u.Scheme + "\n")
+	input.WriteString("host=" + u.Host + "\n")
+	if p := strings.TrimPrefix(u.Path, "/"); p != "" {
+		input.WriteString("path=" + p + "\n")
+	}
+	if u.User != nil {
+		input.WriteString("username=" + u.User.Username() + "\n")
+	}
+	input.WriteString("\n")
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "credential", "fill")
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0", "GCM_INTERACTIVE=never")
+	cmd.Stdin = strings.NewReader(input.String())
+	cmd.Stderr = &stderr
+	output, e := cmd.Output()
+	if e != nil {
+		return "", errors.Join(ErrNotFound, e, errors.New(strings.TrimSpace(stderr.String())))
+	}
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		if password, found := strings.CutPrefix(scanner.Text(), "password="); found && password != "" {
+			t.token = password
+			return t.token, nil
+		}
+	}
+	return "", errors.Join(ErrNotFound, errors.New("git credential: "+u.Host))
+}
@@ -0,0 +1,104 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package credential
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Token from password of netrc machine entry, "default" entry is used if machine is not found
+type Netrc struct {
+	File    string // empty for $NETRC, or ~/.netrc
+	Machine string // host name
+}
+
+func (t *Netrc) Token(ctx context.Context) (string, error) {
+	file := t.File
+	if file == "" {
+		file = os.Getenv("NETRC")
+	}
+	if file == "" {
+		home, e := os.UserHomeDir()
+		if e != nil {
+			return "", e
+		}
+		file = filepath.Join(home, ".netrc")
+	}
+	data, e := os.ReadFile(file)
+	if e != nil {
+		return "", e
+	}
+	var (
+		fields   = netrcFields(string(data))
+		machine  string
+		password = make(map[string]string)
+	)
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			if i++; i < len(fields) {
+				machine = fields[i]
+			}
+		case "default":
+			machine = "default"
+		case "password":
+			if i++; i < len(fields) {
+				if _, found := password[machine]; !found {
+					password[machine] = fields[i]
+				}
+			}
+		}
+	}
+	if token, found := password[t.Machine]; found {
+		return token, nil
+	}
+	if token, found := password["default"]; found {
+		return token, nil
+	}
+	return "", errors.Join(ErrNotFound, errors.New("netrc machine: "+t.Machine))
+}
+
+// Split netrc content into fields, macro definitions(macdef, ends with an empty line) are skipped
+func netrcFields(data string) []string {
+	var (
+		fields []string
+		lines  = strings.Split(data, "\n")
+	)
+	for i := 0; i < len(lines); i++ {
+		for _, field := range strings.Fields(lines[i]) {
+			if field == "macdef" {
+				for i++; i < len(lines) && strings.TrimSpace(lines[i]) != ""; i++ {
+				}
+				break
+			}
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,167 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitapitest
+
+import (
+	"encoding/json"
+	"maps"
+	"slices"
+	"time"
+)
+
+// In-memory repository state of Server
+type Repo struct {
+	Archived       bool
+	CreatedAt      time.Time
+	DefaultBranch  string
+	Description    string
+	Fork           bool
+	Gitignore      string // gitignore template of creation
+	HasActions     bool
+	HasDiscussions bool
+	HasIssues      bool
+	HasProjects    bool
+	HasWiki        bool
+	Homepage       string
+	Id             int64
+	License        string // license template of creation
+	Mirror         bool
+	Name           string
+	Owner          string
+	PendingOwner   string // gitea: owner of pending transfer
+	Private        bool
+	PushedAt       time.Time         // zero if never pushed
+	Secrets        map[string]string // name: decrypted value
+	Template       bool
+	Topics         []string
+	UpdatedAt      time.Time
+	Upstream       string // "OWNER/REPO" of fork
+}
+
+// Return deep copy of repository
+func (t *Repo) Clone() *Repo {
+	repo := *t
+	repo.Secrets = maps.Clone(t.Secrets)
+	repo.Topics = slices.Clone(t.Topics)
+	return &repo
+}
+
+func (t *Repo) visibility() string {
+	if t.Private {
+		return "private"
+	}
+	return "public"
+}
+
+// Repository json of github/gitea dialect
+func (t *Server) output(repo *Repo) map[string]any {
+	var pushedAt *time.Time
+	if !repo.PushedAt.IsZero() {
+		pushedAt = &repo.PushedAt
+	}
+	fullName := repo.Owner + "/" + repo.Name
+	output := map[string]any{
+		"archived":          repo.Archived,
+		"clone_url":         t.URL + "/" + fullName + ".git",
+		"created_at":        repo.CreatedAt,
+		"default_branch":    repo.DefaultBranch,
+		"description":       repo.Description,
+		"fork":              repo.Fork,
+		"forks_count":       0,
+		"full_name":         fullName,
+		"has_issues":        repo.HasIssues,
+		"has_projects":      repo.HasProjects,
+		"has_wiki":          repo.HasWiki,
+		"html_url":          t.URL + "/" + fullName,
+		"id":                repo.Id,
+		"name":              repo.Name,
+		"open_issues_count": 0,
+		"owner":             map[string]any{"id": 1, "login": repo.Owner},
+		"permissions":       map[string]bool{"admin": true, "push": true, "pull": true},
+		"private":           repo.Private,
+		"size":              0,
+		"ssh_url":           "git@" + t.Listener.Addr().String() + ":" + fullName + ".git",
+		"topics":            repo.Topics,
+		"updated_at":        repo.UpdatedAt,
+		"watchers_count":    0,
+	}
+	if t.gitea() {
+		output["empty"] = repo.PushedAt.IsZero()
+		output["has_actions"] = repo.HasActions
+		output["has_pull_requests"] = true
+		output["mirror"] = repo.Mirror
+		output["stars_count"] = 0
+		output["template"] = repo.Template
+		output["website"] = repo.Homepage
+	} else {
+		var mirrorUrl *string
+		if repo.Mirror {
+			mirror := "https://example.com/" + fullName + ".git"
+			mirrorUrl = &mirror
+		}
+		output["has_discussions"] = repo.HasDiscussions
+		output["homepage"] = repo.Homepage
+		output["is_template"] = repo.Template
+		output["mirror_url"] = mirrorUrl
+		output["pushed_at"] = pushedAt
+		output["stargazers_count"] = 0
+		output["visibility"] = repo.visibility()
+	}
+	return output
+}
+
+// Apply PATCH body, unknown fields are ignored
+func (t *Repo) patch(body map[string]json.RawMessage) error {
+	fields := map[string]any{
+		"archived":        &t.Archived,
+		"description":     &t.Description,
+		"has_actions":     &t.HasActions,
+		"has_discussions": &t.HasDiscussions,
+		"has_projects":    &t.HasProjects,
+		"has_issues":      &t.HasIssues,
+		"has_wiki":        &t.HasWiki,
+		"homepage":        &t.Homepage,
+		"is_template":     &t.Template,
+		"template":        &t.Template,
+		"website":         &t.Homepage,
+		"default_branch":  &t.DefaultBranch,
+		"private":         &t.Private,
+	}
+	for k, v := range body {
+		if field, ok := fields[k]; ok {
+			if e := json.Unmarshal(v, field); e != nil {
+				return e
+			}
+		}
+	}
+	if v, ok := body["visibility"]; ok {
+		var visibility string
+		if e := json.Unmarshal(v, &visibility); e != nil {
+			return e
+		}
+		t.Private = visibility != "public"
+	}
+	return nil
+}
@@ -0,0 +1,838 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package gitapitest provides an in-process fake github/gitea server for testing
+// code built on go-gitapi without network access.
+package gitapitest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+	"golang.org/x/crypto/nacl/box"
+)
+
+// Fake github/gitea api server with in-memory state.
+//
+// Implemented endpoints:
+//   - GET /user, GET/POST /user/repos, POST /orgs/ORG/repos
+//   - GET/PATCH/DELETE /repos/OWNER/REPO, PATCH name renames repository
+//   - POST /repos/OWNER/REPO/branches/BRANCH/rename, POST /repos/OWNER/REPO/generate
+//   - GET/POST /repos/OWNER/REPO/forks, POST /repos/OWNER/REPO/merge-upstream
+//   - POST /repos/OWNER/REPO/transfer, POST /repos/OWNER/REPO/transfer/accept|reject (gitea)
+//   - GET/PUT /repos/OWNER/REPO/topics
+//   - PUT/DELETE /repos/OWNER/REPO/actions/secrets/NAME
+//   - GET /repos/OWNER/REPO/actions/secrets/public-key (github)
+//   - GET/PUT /repos/OWNER/REPO/actions/permissions (github)
+//   - GET /meta (github), GET /version, GET /settings/api (gitea)
+//
+// Gitea dialect is served under /api/v1.
+type Server struct {
+	*httptest.Server
+	Orgs    []string // organizations administered by User, gitea transfer to other owners is pending
+	Token   string   // required token, any token is accepted if empty
+	User    string   // authenticated user, owner of created repositories
+	Vendor  vendor.Vendor
+	Version string // gitea version
+
+	mutex      sync.Mutex
+	id         int64
+	keyId      string
+	privateKey *[32]byte
+	publicKey  *[32]byte
+	repos      map[string]*Repo // key: OWNER/REPO
+	requests   []string
+}
+
+// Start fake server of vendor github, gitea or forgejo, with user "user" and token "token".
+//
+// Panic on other vendors. Caller should Close() the server.
+func NewServer(v vendor.Vendor) *Server {
+	if v != vendor.Github && v != vendor.Gitea && v != vendor.Forgejo {
+		panic("gitapitest: unsupported vendor " + v.String())
+	}
+	publicKey, privateKey, e := box.GenerateKey(rand.Reader)
+	if e != nil {
+		panic(e)
+	}
+	t := &Server{
+		Token:      "token",
+		User:       "user",
+		Vendor:     v,
+		Version:    "1.22.0",
+		keyId:      "568250167242549743",
+		privateKey: privateKey,
+		publicKey:  publicKey,
+		repos:      make(map[string]*Repo),
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /meta", t.meta)
+	mux.HandleFunc("GET /version", t.version)
+	mux.HandleFunc("GET /settings/api", t.settings)
+	mux.HandleFunc("GET /user", t.user)
+	mux.HandleFunc("GET /user/repos", t.repoList)
+	mux.HandleFunc("POST /user/repos", t.repoCreate)
+	mux.HandleFunc("POST /orgs/{org}/repos", t.repoCreate)
+	mux.HandleFunc("GET /repos/{owner}/{repo}", t.repoGet)
+	mux.HandleFunc("PATCH /repos/{owner}/{repo}", t.repoPatch)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}", t.repoDel)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/branches/{branch}/rename", t.branchRename)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/generate", t.repoGenerate)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/forks", t.forkList)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/forks", t.forkCreate)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/merge-upstream", t.mergeUpstream)
+	mux.HandleFunc("POST /repos/{owner}/{repo}/transfer", t.transfer)
+	if t.gitea() {
+		mux.HandleFunc("POST /repos/{owner}/{repo}/transfer/{action}", t.transferPending)
+	}
+	mux.HandleFunc("GET /repos/{owner}/{repo}/topics", t.topicsGet)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/topics", t.topicsPut)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/secrets/public-key", t.publicKeyGet)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/actions/secrets/{name}", t.secretPut)
+	mux.HandleFunc("DELETE /repos/{owner}/{repo}/actions/secrets/{name}", t.secretDel)
+	mux.HandleFunc("GET /repos/{owner}/{repo}/actions/permissions", t.actionsGet)
+	mux.HandleFunc("PUT /repos/{owner}/{repo}/actions/permissions", t.actionsPut)
+	var handler http.Handler = mux
+	if t.gitea() {
+		handler = http.StripPrefix(base.ApiPathGitea, mux)
+	}
+	t.Server = httptest.NewServer(t.handler(handler))
+	return t
+}
+
+// Api entry point, with api path for gitea
+func (t *Server) EntryPoint() string {
+	if t.gitea() {
+		return t.URL + base.ApiPathGitea
+	}
+	return t.URL
+}
+
+// Property with EntryPoint, Token, User and Vendor of server, and repo
+func (t *Server) Property(repo string) base.Property {
+	return base.Property{
+		EntryPoint: t.EntryPoint(),
+		Repo:       repo,
+		Token:      t.Token,
+		User:       t.User,
+		Vendor:     t.Vendor,
+	}
+}
+
+// Add repository owned by User
+func (t *Server) AddRepo(name string, private bool) *Repo {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return t.addRepo(t.User, name, private).Clone()
+}
+
+// Replace state of repository, by Owner and Name
+func (t *Server) SetRepo(repo *Repo) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.repos[repo.Owner+"/"+repo.Name] = repo.Clone()
+}
+
+// Return copy of repository, nil if not found
+func (t *Server) Repo(owner, name string) *Repo {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if repo := t.repos[owner+"/"+name]; repo != nil {
+		return repo.Clone()
+	}
+	return nil
+}
+
+// Return requests received, in "METHOD /path" format, api path excluded
+func (t *Server) Requests() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	return slices.Clone(t.requests)
+}
+
+func (t *Server) gitea() bool {
+	return t.Vendor == vendor.Gitea || t.Vendor == vendor.Forgejo
+}
+
+func (t *Server) addRepo(owner, name string, private bool) *Repo {
+	t.id++
+	now := time.Now().UTC().Truncate(time.Second)
+	repo := &Repo{
+		CreatedAt:     now,
+		DefaultBranch: "main",
+		HasActions:    true,
+		HasIssues:     true,
+		HasWiki:       true,
+		Id:            t.id,
+		Name:          name,
+		Owner:         owner,
+		Private:       private,
+		Topics:        []string{},
+		UpdatedAt:     now,
+	}
+	t.repos[owner+"/"+name] = repo
+	return repo
+}
+
+// Log request and check token
+func (t *Server) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.mutex.Lock()
+		t.requests = append(t.requests, r.Method+" "+strings.TrimPrefix(r.URL.Path, base.ApiPathGitea))
+		t.mutex.Unlock()
+		if t.Token != "" && r.Header.Get("Authorization") != "token "+t.Token && r.Header.Get("Authorization") != "Bearer "+t.Token {
+			t.error(w, http.StatusUnauthorized, "Bad credentials")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Error body of github/gitea
+func (t *Server) error(w http.ResponseWriter, status int, message string) {
+	body := map[string]any{"message": message}
+	if t.gitea() {
+		body["url"] = t.URL + "/api/swagger"
+	} else {
+		body["documentation_url"] = "https://docs.github.com/rest"
+	}
+	t.json(w, status, body)
+}
+
+func (t *Server) json(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// Return repository of request path, lock must be held. Write 404 if not found.
+func (t *Server) repo(w http.ResponseWriter, r *http.Request) *Repo {
+	repo := t.repos[r.PathValue("owner")+"/"+r.PathValue("repo")]
+	if repo == nil {
+		t.error(w, http.StatusNotFound, "Not Found")
+	}
+	return repo
+}
+
+func (t *Server) decode(w http.ResponseWriter, r *http.Request, body any) bool {
+	if e := json.NewDecoder(r.Body).Decode(body); e != nil {
+		t.error(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+func (t *Server) meta(w http.ResponseWriter, r *http.Request) {
+	if t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	t.json(w, http.StatusOK, map[string]any{"verifiable_password_authentication": false})
+}
+
+func (t *Server) version(w http.ResponseWriter, r *http.Request) {
+	if !t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	version := t.Version
+	if t.Vendor == vendor.Forgejo {
+		version += "+gitea-" + t.Version
+	}
+	t.json(w, http.StatusOK, map[string]any{"version": version})
+}
+
+func (t *Server) settings(w http.ResponseWriter, r *http.Request) {
+	if !t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	t.json(w, http.StatusOK, map[string]any{"default_paging_num": 30, "max_response_items": 50})
+}
+
+func (t *Server) user(w http.ResponseWriter, r *http.Request) {
+	t.json(w, http.StatusOK, map[string]any{"login": t.User})
+}
+
+// List repositories of User, paginated by page and per_page(github) or limit(gitea)
+func (t *Server) repoList(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	var list []*Repo
+	for _, repo := range t.repos {
+		if repo.Owner == t.User {
+			list = append(list, repo.Clone())
+		}
+	}
+	t.mutex.Unlock()
+	t.page(w, r, list)
+}
+
+// Write page of repositories sorted by OWNER/REPO, with Link header(github, gitea) and X-Total-Count(gitea)
+func (t *Server) page(w http.ResponseWriter, r *http.Request, list []*Repo) {
+	slices.SortFunc(list, func(a, b *Repo) int { return strings.Compare(a.Owner+"/"+a.Name, b.Owner+"/"+b.Name) })
+
+	query := r.URL.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	page = max(page, 1)
+	perPage, _ := strconv.Atoi(query.Get("per_page"))
+	if t.gitea() {
+		perPage, _ = strconv.Atoi(query.Get("limit"))
+	}
+	if perPage <= 0 {
+		perPage = 30
+	}
+	start := min((page-1)*perPage, len(list))
+	end := min(start+perPage, len(list))
+	output := []map[string]any{}
+	for _, repo := range list[start:end] {
+		output = append(output, t.output(repo))
+	}
+	if end < len(list) {
+		next := *r.URL
+		next.Scheme, next.Host = "http", r.Host
+		values := maps.Clone(query)
+		values.Set("page", strconv.Itoa(page+1))
+		next.RawQuery = values.Encode()
+		if t.gitea() {
+			next.Path = base.ApiPathGitea + next.Path
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.String()))
+	}
+	if t.gitea() {
+		w.Header().Set("X-Total-Count", strconv.Itoa(len(list)))
+	}
+	t.json(w, http.StatusOK, output)
+}
+
+// Create repository of User, or organization in path.
+//
+// Github: default_branch is ignored, gitea: has_* and website are ignored, as real servers.
+func (t *Server) repoCreate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		AutoInit      bool   `json:"auto_init"`
+		DefaultBranch string `json:"default_branch"`
+		Description   string `json:"description"`
+		Name          string `json:"name"`
+		Private       bool   `json:"private"`
+		// github
+		GitignoreTemplate string `json:"gitignore_template"`
+		HasIssues         *bool  `json:"has_issues"`
+		HasProjects       *bool  `json:"has_projects"`
+		HasWiki           *bool  `json:"has_wiki"`
+		Homepage          string `json:"homepage"`
+		LicenseTemplate   string `json:"license_template"`
+		// gitea
+		Gitignores string `json:"gitignores"`
+		License    string `json:"license"`
+		Readme     string `json:"readme"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	owner := t.User
+	if org := r.PathValue("org"); org != "" {
+		owner = org
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	switch {
+	case body.Name == "":
+		t.error(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	case t.repos[owner+"/"+body.Name] != nil && t.gitea():
+		t.error(w, http.StatusConflict, "The repository with the same name already exists.")
+		return
+	case t.repos[owner+"/"+body.Name] != nil:
+		t.json(w, http.StatusUnprocessableEntity, map[string]any{
+			"message": "Repository creation failed.",
+			"errors":  []map[string]string{{"resource": "Repository", "code": "custom", "field": "name", "message": "name already exists on this account"}},
+		})
+		return
+	case t.gitea() && body.AutoInit && body.Readme == "":
+		t.error(w, http.StatusUnprocessableEntity, "readme template is required for auto_init")
+		return
+	}
+	repo := t.addRepo(owner, body.Name, body.Private)
+	repo.Description = body.Description
+	if body.AutoInit {
+		repo.PushedAt = repo.CreatedAt
+	}
+	if t.gitea() {
+		repo.Gitignore, repo.License = body.Gitignores, body.License
+		if body.DefaultBranch != "" {
+			repo.DefaultBranch = body.DefaultBranch
+		}
+	} else {
+		repo.Gitignore, repo.License, repo.Homepage = body.GitignoreTemplate, body.LicenseTemplate, body.Homepage
+		for _, option := range []struct {
+			has *bool
+			v   *bool
+		}{{&repo.HasIssues, body.HasIssues}, {&repo.HasProjects, body.HasProjects}, {&repo.HasWiki, body.HasWiki}} {
+			if option.v != nil {
+				*option.has = *option.v
+			}
+		}
+	}
+	t.json(w, http.StatusCreated, t.output(repo))
+}
+
+// Generate repository from template repository
+func (t *Server) repoGenerate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Description string `json:"description"`
+		Name        string `json:"name"`
+		Owner       string `json:"owner"`
+		Private     bool   `json:"private"`
+		// github
+		IncludeAllBranches bool `json:"include_all_branches"`
+		// gitea
+		Avatar     bool `json:"avatar"`
+		GitContent bool `json:"git_content"`
+		GitHooks   bool `json:"git_hooks"`
+		Labels     bool `json:"labels"`
+		Topics     bool `json:"topics"`
+		Webhooks   bool `json:"webhooks"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	template := t.repo(w, r)
+	switch {
+	case template == nil:
+		return
+	case !template.Template:
+		t.error(w, http.StatusUnprocessableEntity, template.Owner+"/"+template.Name+" is not a template repository")
+		return
+	case body.Name == "" || body.Owner == "":
+		t.error(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	case t.gitea() && !(body.Avatar || body.GitContent || body.GitHooks || body.Labels || body.Topics || body.Webhooks):
+		t.error(w, http.StatusUnprocessableEntity, "must select at least one template item")
+		return
+	case t.repos[body.Owner+"/"+body.Name] != nil:
+		t.error(w, http.StatusConflict, "The repository with the same name already exists.")
+		return
+	}
+	repo := t.addRepo(body.Owner, body.Name, body.Private)
+	repo.DefaultBranch, repo.Description = template.DefaultBranch, body.Description
+	if !t.gitea() || body.GitContent {
+		repo.PushedAt = repo.CreatedAt
+	}
+	if t.gitea() && body.Topics {
+		repo.Topics = slices.Clone(template.Topics)
+	}
+	t.json(w, http.StatusCreated, t.output(repo))
+}
+
+// Rename branch, only default branch exists in fake repository
+func (t *Server) branchRename(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		NewName string `json:"new_name"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	if repo.PushedAt.IsZero() || r.PathValue("branch") != repo.DefaultBranch {
+		t.error(w, http.StatusNotFound, "Branch not found")
+		return
+	}
+	repo.DefaultBranch = body.NewName
+	t.json(w, http.StatusCreated, map[string]any{"name": body.NewName})
+}
+
+func (t *Server) repoGet(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if repo := t.repo(w, r); repo != nil {
+		t.json(w, http.StatusOK, t.output(repo))
+	}
+}
+
+func (t *Server) repoPatch(w http.ResponseWriter, r *http.Request) {
+	var body map[string]json.RawMessage
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	var name string
+	if v, ok := body["name"]; ok {
+		if e := json.Unmarshal(v, &name); e != nil || name == "" {
+			t.error(w, http.StatusUnprocessableEntity, "Validation Failed")
+			return
+		}
+	}
+	if name != "" && name != repo.Name && !t.move(w, repo, repo.Owner, name) {
+		return
+	}
+	if e := repo.patch(body); e != nil {
+		t.error(w, http.StatusUnprocessableEntity, e.Error())
+		return
+	}
+	t.json(w, http.StatusOK, t.output(repo))
+}
+
+// List forks of repository
+func (t *Server) forkList(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	upstream := t.repo(w, r)
+	if upstream == nil {
+		t.mutex.Unlock()
+		return
+	}
+	var list []*Repo
+	for _, repo := range t.repos {
+		if repo.Upstream == upstream.Owner+"/"+upstream.Name {
+			list = append(list, repo.Clone())
+		}
+	}
+	t.mutex.Unlock()
+	t.page(w, r, list)
+}
+
+// Fork repository into organization or User
+func (t *Server) forkCreate(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		DefaultBranchOnly bool   `json:"default_branch_only"` // github
+		Name              string `json:"name"`
+		Organization      string `json:"organization"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	upstream := t.repo(w, r)
+	if upstream == nil {
+		return
+	}
+	owner, name := t.User, upstream.Name
+	if body.Organization != "" {
+		owner = body.Organization
+	}
+	if body.Name != "" {
+		name = body.Name
+	}
+	if t.repos[owner+"/"+name] != nil {
+		t.error(w, http.StatusConflict, "repository "+owner+"/"+name+" already exists")
+		return
+	}
+	repo := t.addRepo(owner, name, upstream.Private)
+	repo.DefaultBranch, repo.Description, repo.PushedAt = upstream.DefaultBranch, upstream.Description, upstream.PushedAt
+	repo.Fork, repo.Upstream = true, upstream.Owner+"/"+upstream.Name
+	t.json(w, http.StatusAccepted, t.output(repo))
+}
+
+// Sync branch of fork with upstream, only default branch exists in fake repository
+func (t *Server) mergeUpstream(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Branch string `json:"branch"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	switch {
+	case repo == nil:
+		return
+	case t.repos[repo.Upstream] == nil:
+		t.error(w, http.StatusUnprocessableEntity, "repository is not a fork or upstream not found")
+		return
+	case body.Branch != repo.DefaultBranch:
+		t.error(w, http.StatusNotFound, "Branch not found")
+		return
+	}
+	upstream := t.repos[repo.Upstream]
+	mergeType := "none"
+	if upstream.PushedAt.After(repo.PushedAt) {
+		mergeType, repo.PushedAt = "fast-forward", upstream.PushedAt
+	}
+	if t.gitea() {
+		t.json(w, http.StatusOK, map[string]string{"merge_style": mergeType})
+		return
+	}
+	baseBranch := upstream.Owner + ":" + upstream.DefaultBranch
+	message := "Successfully fetched and fast-forwarded from upstream " + baseBranch + "."
+	if mergeType == "none" {
+		message = "This branch is not behind the upstream " + baseBranch + "."
+	}
+	t.json(w, http.StatusOK, map[string]string{
+		"base_branch": baseBranch,
+		"merge_type":  mergeType,
+		"message":     message,
+	})
+}
+
+// Transfer repository, github: immediate, gitea: pending if new owner is not User or in Orgs
+func (t *Server) transfer(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		NewName  string  `json:"new_name"`
+		NewOwner string  `json:"new_owner"`
+		TeamIds  []int64 `json:"team_ids"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	switch {
+	case repo == nil:
+		return
+	case body.NewOwner == "":
+		t.error(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	case t.gitea() && body.NewOwner != t.User && !slices.Contains(t.Orgs, body.NewOwner):
+		repo.PendingOwner = body.NewOwner
+	default:
+		name := repo.Name
+		if body.NewName != "" && !t.gitea() {
+			name = body.NewName
+		}
+		if !t.move(w, repo, body.NewOwner, name) {
+			return
+		}
+	}
+	t.json(w, http.StatusAccepted, t.output(repo))
+}
+
+// Accept or reject pending transfer, gitea
+func (t *Server) transferPending(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	switch {
+	case repo == nil:
+		return
+	case repo.PendingOwner == "":
+		t.error(w, http.StatusNotFound, "no transfer pending")
+		return
+	case r.PathValue("action") == "accept":
+		if !t.move(w, repo, repo.PendingOwner, repo.Name) {
+			return
+		}
+		repo.PendingOwner = ""
+		t.json(w, http.StatusAccepted, t.output(repo))
+	case r.PathValue("action") == "reject":
+		repo.PendingOwner = ""
+		t.json(w, http.StatusOK, t.output(repo))
+	default:
+		t.error(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// Move repository to owner/name, lock must be held. Write 422 if exists.
+func (t *Server) move(w http.ResponseWriter, repo *Repo, owner, name string) bool {
+	if t.repos[owner+"/"+name] != nil {
+		t.error(w, http.StatusUnprocessableEntity, "repository "+owner+"/"+name+" already exists")
+		return false
+	}
+	delete(t.repos, repo.Owner+"/"+repo.Name)
+	repo.Owner, repo.Name = owner, name
+	repo.UpdatedAt = time.Now().UTC().Truncate(time.Second)
+	t.repos[owner+"/"+name] = repo
+	return true
+}
+
+func (t *Server) repoDel(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if repo := t.repo(w, r); repo != nil {
+		delete(t.repos, repo.Owner+"/"+repo.Name)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// Github topics are "names", gitea topics are "topics"
+func (t *Server) topicsGet(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	if t.gitea() {
+		t.json(w, http.StatusOK, map[string]any{"topics": repo.Topics})
+	} else {
+		t.json(w, http.StatusOK, map[string]any{"names": repo.Topics})
+	}
+}
+
+func (t *Server) topicsPut(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Names  *[]string `json:"names"`
+		Topics *[]string `json:"topics"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	topics := body.Names
+	if t.gitea() {
+		topics = body.Topics
+	}
+	if topics == nil {
+		t.error(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+	repo.Topics = slices.Clone(*topics)
+	if t.gitea() {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		t.json(w, http.StatusOK, map[string]any{"names": repo.Topics})
+	}
+}
+
+func (t *Server) publicKeyGet(w http.ResponseWriter, r *http.Request) {
+	if t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.repo(w, r) != nil {
+		t.json(w, http.StatusOK, map[string]any{"key_id": t.keyId, "key": base64.StdEncoding.EncodeToString(t.publicKey[:])})
+	}
+}
+
+// Github: decrypt encrypted_value with key_id. Gitea: plain data.
+func (t *Server) secretPut(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Data           string `json:"data"`
+		EncryptedValue string `json:"encrypted_value"`
+		KeyId          string `json:"key_id"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	value := body.Data
+	if !t.gitea() {
+		sealed, e := base64.StdEncoding.DecodeString(body.EncryptedValue)
+		decrypted, ok := box.OpenAnonymous(nil, sealed, t.publicKey, t.privateKey)
+		if e != nil || !ok || body.KeyId != t.keyId {
+			t.error(w, http.StatusUnprocessableEntity, "Bad request: encrypted_value or key_id")
+			return
+		}
+		value = string(decrypted)
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	if repo.Secrets == nil {
+		repo.Secrets = make(map[string]string)
+	}
+	name := strings.ToUpper(r.PathValue("name"))
+	_, exist := repo.Secrets[name]
+	repo.Secrets[name] = value
+	if exist {
+		w.WriteHeader(http.StatusNoContent)
+	} else {
+		w.WriteHeader(http.StatusCreated)
+	}
+}
+
+func (t *Server) secretDel(w http.ResponseWriter, r *http.Request) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	repo := t.repo(w, r)
+	if repo == nil {
+		return
+	}
+	name := strings.ToUpper(r.PathValue("name"))
+	if _, exist := repo.Secrets[name]; !exist {
+		t.error(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(repo.Secrets, name)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (t *Server) actionsGet(w http.ResponseWriter, r *http.Request) {
+	if t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if repo := t.repo(w, r); repo != nil {
+		t.json(w, http.StatusOK, map[string]any{"enabled": repo.HasActions, "allowed_actions": "all"})
+	}
+}
+
+func (t *Server) actionsPut(w http.ResponseWriter, r *http.Request) {
+	if t.gitea() {
+		http.NotFound(w, r)
+		return
+	}
+	var body struct {
+		Enabled *bool `json:"enabled"`
+	}
+	if !t.decode(w, r, &body) {
+		return
+	}
+	if body.Enabled == nil {
+		t.error(w, http.StatusUnprocessableEntity, "Invalid request: enabled is required")
+		return
+	}
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if repo := t.repo(w, r); repo != nil {
+		repo.HasActions = *body.Enabled
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
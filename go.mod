@@ -1,4 +1,4 @@
-module github.com/J-Siu/go-gitapi/v3
+module github.com/J-Siu/go-gitapi/v4
 
 go 1.25.5
 
@@ -6,10 +6,10 @@ require (
 	github.com/J-Siu/go-crypto v1.1.4
 	github.com/J-Siu/go-helper/v2 v2.7.2
 	github.com/J-Siu/go-restapi v1.0.4
+	golang.org/x/crypto v0.49.0
 )
 
 require (
 	github.com/charlievieth/strcase v0.0.5 // indirect
-	golang.org/x/crypto v0.49.0 // indirect
 	golang.org/x/sys v0.42.0 // indirect
 )
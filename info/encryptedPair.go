@@ -26,13 +26,26 @@ package info
 
 // Github repository action secret structure
 type EncryptedPair struct {
-	Encrypted_value string `json:"encrypted_value"` // Encrypted value
-	Key_id          string `json:"key_id"`          // Public key id
-}
+	Encrypted_value string `json:"encrypted_value,omitempty"` // Encrypted value
+	Key_id          string `json:"key_id,omitempty"`          // Public key id
+
+	Data string `json:"data,omitempty"` // Gitea, Forgejo secret value
 
+	Key   string `json:"key,omitempty"`   // Gitlab CI/CD variable key
+	Value string `json:"value,omitempty"` // Gitlab CI/CD variable value
+}
 
 func (t *EncryptedPair) StringP() *string {
 	var str string
+	if t.Data != "" {
+		// Gitea, Forgejo, value is not shown
+		return &str
+	}
+	if t.Key != "" {
+		// Gitlab, value is not shown
+		str += "Key:" + t.Key + "\n"
+		return &str
+	}
 	str += "Value:" + t.Encrypted_value + "\n"
 	str += "Key ID:" + t.Key_id + "\n"
 	return &str
@@ -0,0 +1,113 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package info
+
+import (
+	"encoding/json"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Fork creation
+//
+// Request body is marshalled per Vendor:
+//   - github: organization, name, default_branch_only
+//   - gitea, forgejo, gogs: organization, name
+//   - gitlab: namespace_path, name and path
+//
+// Created fork is unmarshalled into Repository.
+type Fork struct {
+	Organization      string `json:"organization,omitempty"` // empty for authenticated user
+	Name              string `json:"name,omitempty"`         // empty to keep upstream name
+	DefaultBranchOnly bool   `json:"default_branch_only,omitempty"`
+
+	Repository Repository    `json:"-"` // created fork
+	Vendor     vendor.Vendor `json:"-"` // set by api.Fork
+}
+
+func (t *Fork) MarshalJSON() ([]byte, error) {
+	body := map[string]any{}
+	switch t.Vendor {
+	case vendor.Gitlab:
+		setNonEmpty(body, "namespace_path", t.Organization)
+		setNonEmpty(body, "name", t.Name)
+		setNonEmpty(body, "path", t.Name)
+		return json.Marshal(body)
+	case vendor.Github:
+		if t.DefaultBranchOnly {
+			body["default_branch_only"] = true
+		}
+	}
+	setNonEmpty(body, "organization", t.Organization)
+	setNonEmpty(body, "name", t.Name)
+	return json.Marshal(body)
+}
+
+func (t *Fork) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &t.Repository)
+}
+
+func (t *Fork) StringP() *string {
+	return t.Repository.StringP()
+}
+
+func (t *Fork) String() string {
+	return *t.StringP()
+}
+
+// Fork branch sync with upstream
+type ForkSync struct {
+	Branch string `json:"branch"`
+
+	// response
+	BaseBranch string `json:"base_branch,omitempty"` // github
+	MergeType  string `json:"merge_type,omitempty"`  // "fast-forward", "merge", "none", gitea: merge_style
+	Message    string `json:"message,omitempty"`     // github
+}
+
+func (t *ForkSync) UnmarshalJSON(b []byte) error {
+	type forkSync ForkSync
+	aux := struct {
+		*forkSync
+		MergeStyle string `json:"merge_style"` // gitea
+	}{forkSync: (*forkSync)(t)}
+	if e := json.Unmarshal(b, &aux); e != nil {
+		return e
+	}
+	first(&t.MergeType, aux.MergeStyle)
+	return nil
+}
+
+func (t *ForkSync) StringP() *string {
+	str := t.Branch + " (" + t.MergeType + ")"
+	if t.Message != "" {
+		str += ": " + t.Message
+	}
+	return &str
+}
+
+func (t *ForkSync) String() string {
+	return *t.StringP()
+}
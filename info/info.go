@@ -25,18 +25,95 @@ THE SOFTWARE.
 package info
 
 import (
+	"encoding/json"
 	"strconv"
+	"strings"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository(creation) info structure
+//
+// Request body is marshalled per Vendor:
+//   - gitea, forgejo, gogs: gitignores, license, readme; homepage and has_* are set after creation
+//   - gitlab: initialize_with_readme, issues_enabled, wiki_enabled; templates are not supported
 type Info struct {
-	Name    string `json:"name"`
-	Private bool   `json:"private"`
+	Name       string `json:"name"`
+	Private    bool   `json:"private"`
+	Visibility string `json:"visibility,omitempty"` // gitlab: public, private, internal, from Private if empty
+
+	AutoInit          bool   `json:"auto_init,omitempty"`
+	DefaultBranch     string `json:"default_branch,omitempty"` // github: renamed after creation, requires AutoInit
+	Description       string `json:"description,omitempty"`
+	GitignoreTemplate string `json:"gitignore_template,omitempty"` // eg. "Go"
+	Homepage          string `json:"homepage,omitempty"`
+	LicenseTemplate   string `json:"license_template,omitempty"` // eg. "mit", gitea: "MIT"
+
+	HasIssues   *bool `json:"has_issues,omitempty"`
+	HasProjects *bool `json:"has_projects,omitempty"`
+	HasWiki     *bool `json:"has_wiki,omitempty"`
+
+	Vendor vendor.Vendor `json:"-"` // set by api.Repo
 }
 
+func (t *Info) MarshalJSON() ([]byte, error) {
+	type info Info
+	switch t.Vendor {
+	case vendor.Gitea, vendor.Forgejo, vendor.Gogs:
+		body := map[string]any{
+			"name":    t.Name,
+			"private": t.Private,
+		}
+		if t.AutoInit {
+			body["auto_init"] = true
+			body["readme"] = "Default"
+		}
+		setNonEmpty(body, "description", t.Description)
+		setNonEmpty(body, "default_branch", t.DefaultBranch)
+		setNonEmpty(body, "gitignores", t.GitignoreTemplate)
+		setNonEmpty(body, "license", t.LicenseTemplate)
+		return json.Marshal(body)
+	case vendor.Gitlab:
+		visibility := t.Visibility
+		if visibility == "" && t.Private {
+			visibility = "private"
+		} else if visibility == "" {
+			visibility = "public"
+		}
+		body := map[string]any{
+			"name":       t.Name,
+			"visibility": visibility,
+		}
+		if t.AutoInit {
+			body["initialize_with_readme"] = true
+		}
+		setNonEmpty(body, "description", t.Description)
+		setNonEmpty(body, "default_branch", t.DefaultBranch)
+		setNonNil(body, "issues_enabled", t.HasIssues)
+		setNonNil(body, "wiki_enabled", t.HasWiki)
+		return json.Marshal(body)
+	}
+	github := info(*t)
+	github.DefaultBranch = ""
+	return json.Marshal(&github)
+}
+
+// Set body[k] if v is not empty
+func setNonEmpty(body map[string]any, k, v string) {
+	if strings.TrimSpace(v) != "" {
+		body[k] = v
+	}
+}
+
+// Set body[k] if v is not nil
+func setNonNil(body map[string]any, k string, v *bool) {
+	if v != nil {
+		body[k] = *v
+	}
+}
 
 func (t *Info) StringP() *string {
-	str := t.Name + " (private:" + strconv.FormatBool(t.Private) + ")"
+	str := t.Name + " (private:" + strconv.FormatBool(t.Private || t.Visibility == "private") + ")"
 	return &str
 }
 
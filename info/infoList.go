@@ -24,8 +24,8 @@ THE SOFTWARE.
 
 package info
 
-// Github repository(creation) info array
-type InfoList []Info
+// Repository list
+type InfoList []Repository
 
 func (t *InfoList) StringP() *string {
 	var str string
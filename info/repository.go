@@ -0,0 +1,177 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package info
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Repository owner
+type Owner struct {
+	Id    int64  `json:"id"`
+	Login string `json:"login"`
+	Type  string `json:"type,omitempty"` // github: User, Organization
+}
+
+// Permissions of authenticated user on repository
+type Permissions struct {
+	Admin bool `json:"admin"`
+	Pull  bool `json:"pull"`
+	Push  bool `json:"push"`
+}
+
+// Repository structure, normalized from github, gitea and gitlab.
+//
+// Json fields use github names. Gitea and gitlab names are mapped on unmarshal.
+type Repository struct {
+	Id            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"` // gitlab: path_with_namespace
+	Owner         Owner  `json:"owner"`     // gitlab: namespace
+	Description   string `json:"description"`
+	Homepage      string `json:"homepage"` // gitea: website
+	DefaultBranch string `json:"default_branch"`
+
+	CloneUrl string `json:"clone_url"` // gitlab: http_url_to_repo
+	HtmlUrl  string `json:"html_url"`  // gitlab: web_url
+	SshUrl   string `json:"ssh_url"`   // gitlab: ssh_url_to_repo
+
+	Archived   bool   `json:"archived"`
+	Empty      bool   `json:"empty"` // gitea
+	Fork       bool   `json:"fork"`
+	Mirror     bool   `json:"mirror"`      // github: mirror_url is set
+	Private    bool   `json:"private"`     // gitlab: visibility is private
+	Template   bool   `json:"is_template"` // gitea: template
+	Visibility string `json:"visibility"`  // public, private, internal
+
+	CreatedAt time.Time `json:"created_at"`
+	PushedAt  time.Time `json:"pushed_at"` // gitlab: last_activity_at, gitea: not available
+	UpdatedAt time.Time `json:"updated_at"`
+
+	Forks      int   `json:"forks_count"`
+	OpenIssues int   `json:"open_issues_count"`
+	Size       int64 `json:"size"`             // KB
+	Stars      int   `json:"stargazers_count"` // gitea: stars_count, gitlab: star_count
+	Watchers   int   `json:"watchers_count"`
+
+	Permissions *Permissions `json:"permissions,omitempty"` // nil if not returned
+
+	HasActions      bool `json:"has_actions"`     // gitea, gitlab: jobs_enabled
+	HasDiscussions  bool `json:"has_discussions"` // github
+	HasIssues       bool `json:"has_issues"`      // gitlab: issues_enabled
+	HasProjects     bool `json:"has_projects"`
+	HasPullRequests bool `json:"has_pull_requests"` // gitea, github: always true
+	HasWiki         bool `json:"has_wiki"`          // gitlab: wiki_enabled
+
+	Topics []string `json:"topics"`
+}
+
+func (t *Repository) UnmarshalJSON(data []byte) error {
+	// Fields below are merged, no value is kept from previous decoding
+	*t = Repository{}
+	type repository Repository
+	aux := struct {
+		*repository
+		// github
+		MirrorUrl string `json:"mirror_url"`
+		// gitea
+		Internal   bool   `json:"internal"`
+		StarsCount int    `json:"stars_count"`
+		Template   bool   `json:"template"`
+		Website    string `json:"website"`
+		// gitlab
+		ForkedFromProject *struct{} `json:"forked_from_project"`
+		HttpUrlToRepo     string    `json:"http_url_to_repo"`
+		IssuesEnabled     *bool     `json:"issues_enabled"`
+		JobsEnabled       *bool     `json:"jobs_enabled"`
+		LastActivityAt    time.Time `json:"last_activity_at"`
+		Namespace         *struct {
+			Id       int64  `json:"id"`
+			FullPath string `json:"full_path"`
+			Kind     string `json:"kind"`
+		} `json:"namespace"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		SshUrlToRepo      string `json:"ssh_url_to_repo"`
+		StarCount         int    `json:"star_count"`
+		WebUrl            string `json:"web_url"`
+		WikiEnabled       *bool  `json:"wiki_enabled"`
+	}{repository: (*repository)(t)}
+	if e := json.Unmarshal(data, &aux); e != nil {
+		return e
+	}
+	t.Mirror = t.Mirror || aux.MirrorUrl != ""
+	t.Template = t.Template || aux.Template
+	t.Stars = max(t.Stars, aux.StarsCount, aux.StarCount)
+	first(&t.Homepage, aux.Website)
+	// gitlab
+	t.Fork = t.Fork || aux.ForkedFromProject != nil
+	first(&t.CloneUrl, aux.HttpUrlToRepo)
+	first(&t.FullName, aux.PathWithNamespace)
+	first(&t.HtmlUrl, aux.WebUrl)
+	first(&t.SshUrl, aux.SshUrlToRepo)
+	if t.PushedAt.IsZero() {
+		t.PushedAt = aux.LastActivityAt
+	}
+	if aux.Namespace != nil && t.Owner.Login == "" {
+		t.Owner = Owner{Id: aux.Namespace.Id, Login: aux.Namespace.FullPath, Type: aux.Namespace.Kind}
+	}
+	for _, enabled := range []struct {
+		has *bool
+		v   *bool
+	}{{&t.HasActions, aux.JobsEnabled}, {&t.HasIssues, aux.IssuesEnabled}, {&t.HasWiki, aux.WikiEnabled}} {
+		if enabled.v != nil {
+			*enabled.has = *enabled.v
+		}
+	}
+	switch {
+	case t.Visibility == "" && aux.Internal:
+		t.Visibility = "internal"
+	case t.Visibility == "" && t.Private:
+		t.Visibility = "private"
+	case t.Visibility == "":
+		t.Visibility = "public"
+	case t.Visibility == "private":
+		t.Private = true
+	}
+	return nil
+}
+
+// Set s to v if s is empty
+func first(s *string, v string) {
+	if *s == "" {
+		*s = v
+	}
+}
+
+func (t *Repository) StringP() *string {
+	str := t.Name + " (private:" + strconv.FormatBool(t.Private) + ")"
+	return &str
+}
+
+func (t *Repository) String() string {
+	return *t.StringP()
+}
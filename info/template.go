@@ -0,0 +1,99 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package info
+
+import (
+	"encoding/json"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Repository generation from template
+//
+// Request body is marshalled per Vendor:
+//   - github: include_all_branches
+//   - gitea, forgejo: git_content, topics, labels, webhooks, git_hooks, avatar; at least one is required by gitea
+//
+// Generated repository is unmarshalled into Repository.
+type Template struct {
+	Owner       string `json:"owner,omitempty"` // Property.User if empty
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+
+	IncludeAllBranches bool `json:"include_all_branches,omitempty"` // github
+
+	// gitea
+	Avatar     bool `json:"avatar,omitempty"`
+	GitContent bool `json:"git_content,omitempty"`
+	GitHooks   bool `json:"git_hooks,omitempty"`
+	Labels     bool `json:"labels,omitempty"`
+	Topics     bool `json:"topics,omitempty"`
+	Webhooks   bool `json:"webhooks,omitempty"`
+
+	Repository Repository    `json:"-"` // generated repository
+	Vendor     vendor.Vendor `json:"-"` // set by api.Repo
+}
+
+func (t *Template) MarshalJSON() ([]byte, error) {
+	body := map[string]any{
+		"owner":   t.Owner,
+		"name":    t.Name,
+		"private": t.Private,
+	}
+	setNonEmpty(body, "description", t.Description)
+	switch t.Vendor {
+	case vendor.Gitea, vendor.Forgejo:
+		for k, v := range map[string]bool{
+			"avatar":      t.Avatar,
+			"git_content": t.GitContent,
+			"git_hooks":   t.GitHooks,
+			"labels":      t.Labels,
+			"topics":      t.Topics,
+			"webhooks":    t.Webhooks,
+		} {
+			if v {
+				body[k] = true
+			}
+		}
+	default:
+		if t.IncludeAllBranches {
+			body["include_all_branches"] = true
+		}
+	}
+	return json.Marshal(body)
+}
+
+func (t *Template) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &t.Repository)
+}
+
+func (t *Template) StringP() *string {
+	return t.Repository.StringP()
+}
+
+func (t *Template) String() string {
+	return *t.StringP()
+}
@@ -0,0 +1,81 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package info
+
+import (
+	"encoding/json"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Repository rename and transfer
+//
+// Request body is marshalled per Vendor:
+//   - gitea, forgejo, gogs: new_name is not supported
+//   - gitlab: name and path on rename, namespace on transfer
+//
+// Repository of response is unmarshalled into Repository.
+type Transfer struct {
+	Name     string  `json:"name,omitempty"`      // rename
+	NewOwner string  `json:"new_owner,omitempty"` // transfer
+	NewName  string  `json:"new_name,omitempty"`  // transfer, github
+	TeamIds  []int64 `json:"team_ids,omitempty"`  // transfer into organization
+
+	Repository Repository    `json:"-"` // repository of response
+	Vendor     vendor.Vendor `json:"-"` // set by api.Repo
+}
+
+func (t *Transfer) MarshalJSON() ([]byte, error) {
+	body := map[string]any{}
+	switch t.Vendor {
+	case vendor.Gitlab:
+		if t.Name != "" {
+			body["name"] = t.Name
+			body["path"] = t.Name
+		}
+		setNonEmpty(body, "namespace", t.NewOwner)
+		return json.Marshal(body)
+	case vendor.Github:
+		setNonEmpty(body, "new_name", t.NewName)
+	}
+	setNonEmpty(body, "name", t.Name)
+	setNonEmpty(body, "new_owner", t.NewOwner)
+	if len(t.TeamIds) > 0 {
+		body["team_ids"] = t.TeamIds
+	}
+	return json.Marshal(body)
+}
+
+func (t *Transfer) UnmarshalJSON(b []byte) error {
+	return json.Unmarshal(b, &t.Repository)
+}
+
+func (t *Transfer) StringP() *string {
+	return t.Repository.StringP()
+}
+
+func (t *Transfer) String() string {
+	return *t.StringP()
+}
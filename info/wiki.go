@@ -25,16 +25,37 @@ THE SOFTWARE.
 package info
 
 import (
+	"encoding/json"
 	"strconv"
+
+	"github.com/J-Siu/go-gitapi/v4/vendor"
 )
 
 // Github repository Wiki structure
+//
+// Request body is marshalled per Vendor: wiki_enabled for gitlab, has_wiki for others.
 type Wiki struct {
-	Has bool `json:"has_wiki"`
+	Enabled bool `json:"wiki_enabled"` // gitlab
+	Has     bool `json:"has_wiki"`
+
+	Vendor vendor.Vendor `json:"-"` // set by api.Wiki
+}
+
+func (t *Wiki) Set(enable bool) *Wiki {
+	t.Enabled = enable
+	t.Has = enable
+	return t
+}
+
+func (t *Wiki) MarshalJSON() ([]byte, error) {
+	if t.Vendor == vendor.Gitlab {
+		return json.Marshal(map[string]bool{"wiki_enabled": t.Enabled})
+	}
+	return json.Marshal(map[string]bool{"has_wiki": t.Has})
 }
 
 func (t *Wiki) String() string {
-	return strconv.FormatBool(t.Has)
+	return strconv.FormatBool(t.Has || t.Enabled)
 }
 
 func (t *Wiki) StringP() *string {
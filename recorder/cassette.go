@@ -0,0 +1,84 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package recorder
+
+import (
+	"encoding/json"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// Recorded request, credentials and secrets redacted
+type Request struct {
+	Method string      `json:"method"`
+	Url    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body,omitempty"`
+}
+
+// Recorded response, credentials and secrets redacted
+type Response struct {
+	Status     string      `json:"status"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Request and response pair
+type Interaction struct {
+	Request  Request  `json:"request"`
+	Response Response `json:"response"`
+}
+
+// Interactions stored in a json file
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Load cassette from json file
+func Load(file string) (*Cassette, error) {
+	data, e := os.ReadFile(file)
+	if e != nil {
+		return nil, e
+	}
+	cassette := new(Cassette)
+	if e = json.Unmarshal(data, cassette); e != nil {
+		return nil, e
+	}
+	return cassette, nil
+}
+
+// Save cassette to json file, directory is created if not exist
+func (t *Cassette) Save(file string) error {
+	data, e := json.MarshalIndent(t, "", "  ")
+	if e != nil {
+		return e
+	}
+	if e = os.MkdirAll(filepath.Dir(file), 0o755); e != nil {
+		return e
+	}
+	return os.WriteFile(file, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,196 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+// Package recorder provides a record/replay http.RoundTripper for base.Property.Transport,
+// so tests of api flows can run offline.
+package recorder
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+// Returned by replay if request does not match next interaction
+var ErrMismatch = errors.New("recorder: request mismatch")
+
+type Mode int
+
+const (
+	ModeAuto   Mode = iota // replay if cassette file exists, else record
+	ModeRecord             // send requests, record interactions, overwrite cassette on Close()
+	ModeReplay             // replay interactions in order, no request is sent
+)
+
+// Record/replay http.RoundTripper.
+//
+// Authorization headers and secret body fields are redacted before recording, using base.RedactHeader and base.RedactBody.
+// Replay is strict: requests must match method, url and redacted body of recorded interactions in order.
+type Recorder struct {
+	File      string
+	Mode      Mode              // ModeAuto is resolved on New()
+	Transport http.RoundTripper // used for recording, default http.DefaultTransport
+
+	mutex    sync.Mutex
+	cassette *Cassette
+	next     int // next interaction to replay
+}
+
+// Return recorder of cassette file. Cassette is loaded for replay.
+func New(file string, mode Mode) (*Recorder, error) {
+	t := &Recorder{File: file, Mode: mode}
+	if t.Mode == ModeAuto {
+		t.Mode = ModeRecord
+		if _, e := os.Stat(file); e == nil {
+			t.Mode = ModeReplay
+		}
+	}
+	if t.Mode == ModeReplay {
+		var e error
+		if t.cassette, e = Load(file); e != nil {
+			return nil, e
+		}
+	} else {
+		t.cassette = new(Cassette)
+	}
+	return t, nil
+}
+
+func (t *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var e error
+		if body, e = io.ReadAll(req.Body); e != nil {
+			return nil, e
+		}
+		req.Body.Close()
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	request := Request{
+		Method: req.Method,
+		Url:    req.URL.String(),
+		Header: base.RedactHeader(req.Header),
+		Body:   string(base.RedactBody(body)),
+	}
+	if t.Mode == ModeReplay {
+		return t.replay(req, &request)
+	}
+	return t.record(req, &request)
+}
+
+func (t *Recorder) record(req *http.Request, request *Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	res, e := transport.RoundTrip(req)
+	if e != nil {
+		return nil, e
+	}
+	body, e := io.ReadAll(res.Body)
+	res.Body.Close()
+	if e != nil {
+		return nil, e
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Request: *request,
+		Response: Response{
+			Status:     res.Status,
+			StatusCode: res.StatusCode,
+			Header:     base.RedactHeader(res.Header),
+			Body:       string(base.RedactBody(body)),
+		},
+	})
+	return res, nil
+}
+
+func (t *Recorder) replay(req *http.Request, request *Request) (*http.Response, error) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.next >= len(t.cassette.Interactions) {
+		return nil, fmt.Errorf("%w: %s %s, no interaction left", ErrMismatch, request.Method, request.Url)
+	}
+	interaction := t.cassette.Interactions[t.next]
+	if e := match(&interaction.Request, request); e != nil {
+		return nil, e
+	}
+	t.next++
+	response := interaction.Response
+	return &http.Response{
+		Status:        response.Status,
+		StatusCode:    response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        response.Header.Clone(),
+		Body:          io.NopCloser(strings.NewReader(response.Body)),
+		ContentLength: int64(len(response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Match method, url and body, json body is compared by value
+func match(recorded, request *Request) error {
+	if recorded.Method != request.Method || recorded.Url != request.Url {
+		return fmt.Errorf("%w: %s %s, want %s %s", ErrMismatch, request.Method, request.Url, recorded.Method, recorded.Url)
+	}
+	if recorded.Body != request.Body && !jsonEqual(recorded.Body, request.Body) {
+		return fmt.Errorf("%w: %s %s body %s, want %s", ErrMismatch, request.Method, request.Url, request.Body, recorded.Body)
+	}
+	return nil
+}
+
+func jsonEqual(a, b string) bool {
+	var va, vb any
+	if json.Unmarshal([]byte(a), &va) != nil || json.Unmarshal([]byte(b), &vb) != nil {
+		return false
+	}
+	ja, _ := json.Marshal(va)
+	jb, _ := json.Marshal(vb)
+	return bytes.Equal(ja, jb)
+}
+
+// Record: save cassette. Replay: return error if not all interactions are replayed.
+func (t *Recorder) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.Mode == ModeReplay {
+		if left := len(t.cassette.Interactions) - t.next; left > 0 {
+			return fmt.Errorf("%w: %d interaction(s) not replayed", ErrMismatch, left)
+		}
+		return nil
+	}
+	return t.cassette.Save(t.File)
+}
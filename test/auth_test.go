@@ -0,0 +1,97 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestAuthScheme(t *testing.T) {
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	basic := func(login, password string) string {
+		return "Basic " + base64.StdEncoding.EncodeToString([]byte(login+":"+password))
+	}
+	for _, test := range []struct {
+		name     string
+		property base.Property
+		want     map[string]string
+		err      error
+	}{
+		{"github token", base.Property{Vendor: vendor.Github, Token: "pat"},
+			map[string]string{"Authorization": "token pat"}, nil},
+		{"gitlab default", base.Property{Vendor: vendor.Gitlab, Token: "pat"},
+			map[string]string{"Private-Token": "pat", "Authorization": ""}, nil},
+		{"gitlab bearer sudo", base.Property{Vendor: vendor.Gitlab, Token: "oauth", AuthScheme: base.AuthBearer, Sudo: "alice"},
+			map[string]string{"Authorization": "Bearer oauth", "Private-Token": "", "Sudo": "alice"}, nil},
+		{"gitea basic otp", base.Property{Vendor: vendor.Gitea, User: "org", Login: "alice", Password: "pw", AuthScheme: base.AuthBasic, Otp: "123456"},
+			map[string]string{"Authorization": basic("alice", "pw"), "X-Gitea-Otp": "123456"}, nil},
+		{"gitea basic token", base.Property{Vendor: vendor.Gitea, User: "alice", Token: "pat", AuthScheme: base.AuthBasic},
+			map[string]string{"Authorization": basic("alice", "pat")}, nil},
+		{"gitea sudo", base.Property{Vendor: vendor.Gitea, Token: "admin", Sudo: "alice"},
+			map[string]string{"Authorization": "token admin", "Sudo": "alice"}, nil},
+		{"github otp", base.Property{Vendor: vendor.Github, Login: "alice", Password: "pw", AuthScheme: base.AuthBasic, Otp: "123456"},
+			map[string]string{"Authorization": basic("alice", "pw"), "X-Github-Otp": "123456"}, nil},
+		{"github sudo", base.Property{Vendor: vendor.Github, Token: "pat", Sudo: "alice"}, nil, base.ErrUnsupported},
+		{"gitlab basic", base.Property{Vendor: vendor.Gitlab, Password: "pw", AuthScheme: base.AuthBasic}, nil, base.ErrUnsupported},
+		{"gitlab otp", base.Property{Vendor: vendor.Gitlab, Token: "pat", Otp: "123456"}, nil, base.ErrUnsupported},
+		{"unknown", base.Property{Token: "pat", AuthScheme: "digest"}, nil, base.ErrAuth},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			header = nil
+			test.property.EntryPoint = server.URL
+			test.property.Repo = "repo"
+			description := new(api.Description).New(&test.property).Get()
+			e := description.Do().Err()
+			if test.err != nil {
+				if !errors.Is(e, test.err) || !errors.Is(e, base.ErrAuth) || header != nil {
+					t.Fatalf("error: %v, request sent: %v", e, header != nil)
+				}
+				return
+			}
+			if e != nil {
+				t.Fatal(e)
+			}
+			for k, v := range test.want {
+				if header.Get(k) != v {
+					t.Errorf("%s: %q, want %q", k, header.Get(k), v)
+				}
+			}
+		})
+	}
+}
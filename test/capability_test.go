@@ -0,0 +1,86 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestCapabilityProbe(t *testing.T) {
+	var tests = []struct {
+		vendor  vendor.Vendor
+		version string // "" for no version endpoint
+		actions bool
+		topics  bool
+	}{
+		{vendor.Gitea, "1.18.5", false, true},
+		{vendor.Gitea, "1.22.1", true, true},
+		{vendor.Forgejo, "7.0.5+gitea-1.21.11", true, true},
+		{vendor.Gogs, "", false, false},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/api/v1/version":
+				if test.version != "" {
+					w.Write([]byte(`{"version":"` + test.version + `"}`))
+					return
+				}
+			case "/api/v1/settings/api":
+				if test.vendor != vendor.Gogs {
+					w.Write([]byte(`{"max_response_items":50}`))
+					return
+				}
+			}
+			http.NotFound(w, r)
+		}))
+		property := base.Property{EntryPoint: server.URL + "/api/v1", Vendor: test.vendor, User: "user", Repo: "repo"}
+		capability, e := base.Probe(t.Context(), &property)
+		if e != nil {
+			t.Fatalf("%s %s: %v", test.vendor, test.version, e)
+		}
+		if property.Capability != capability || capability.Version != test.version {
+			t.Fatalf("%s %s: capability not saved %#v", test.vendor, test.version, property.Capability)
+		}
+		if capability.Supports(base.FeatureActions) != test.actions || capability.Supports(base.FeatureTopics) != test.topics {
+			t.Fatalf("%s %s: unexpected features %v", test.vendor, test.version, capability.Features)
+		}
+		if test.vendor != vendor.Gogs && capability.MaxResponseItems != 50 {
+			t.Fatalf("%s %s: max response items %d", test.vendor, test.version, capability.MaxResponseItems)
+		}
+		actions := new(api.Actions).New(&property).Get()
+		if e := actions.Do().Err(); !test.actions && !errors.Is(e, base.ErrUnsupported) {
+			t.Fatalf("%s %s: actions should be unsupported: %v", test.vendor, test.version, e)
+		}
+		server.Close()
+	}
+}
@@ -0,0 +1,69 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+func TestDoContextDeadline(t *testing.T) {
+	var (
+		done   = make(chan struct{})
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			select {
+			case <-done:
+			case <-r.Context().Done():
+			}
+		}))
+	)
+	defer server.Close()
+	defer close(done)
+
+	var (
+		property = base.Property{
+			EntryPoint: server.URL,
+			Repo:       "repo",
+			User:       "user",
+		}
+		ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	)
+	defer cancel()
+
+	topics := new(api.Topics).New(&property).Get()
+	if topics.DoContext(ctx).Ok() {
+		t.Fatal("request should fail on deadline")
+	}
+	if e := topics.Err(); !errors.Is(e, context.DeadlineExceeded) || !errors.Is(e, base.ErrTransport) {
+		t.Fatalf("unexpected error: %v", e)
+	}
+}
@@ -0,0 +1,130 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestRepoCreateOptions(t *testing.T) {
+	disabled := false
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			property := server.Property("")
+
+			repo := new(api.Repo).New(&property)
+			repo.Info.Name = "svc"
+			repo.Info.Private = true
+			repo.Info.AutoInit = true
+			repo.Info.DefaultBranch = "trunk"
+			repo.Info.Description = "service"
+			repo.Info.GitignoreTemplate = "Go"
+			repo.Info.Homepage = "https://svc.dev"
+			repo.Info.LicenseTemplate = "mit"
+			repo.Info.HasIssues = &disabled
+			repo.Info.HasWiki = &disabled
+			if e := repo.CreateOrg("org").Do().Err(); e != nil {
+				t.Fatal(e)
+			}
+			if repo.Info.DefaultBranch != "trunk" {
+				t.Fatalf("info: %+v", repo.Info)
+			}
+			got := server.Repo("org", "svc")
+			if got == nil {
+				t.Fatalf("not created: %v", server.Requests())
+			}
+			s := fmt.Sprint(got.Private, got.DefaultBranch, got.Description, got.Gitignore, got.License, got.Homepage, got.HasIssues, got.HasWiki)
+			if s != fmt.Sprint(true, "trunk", "service", "Go", "mit", "https://svc.dev", false, false) {
+				t.Fatalf("state: %s", s)
+			}
+			if server.Repo("user", "svc") != nil {
+				t.Fatal("created in user")
+			}
+
+			// user repo, no follow-up request
+			before := len(server.Requests())
+			repo = new(api.Repo).New(&property)
+			repo.Info.Name = "plain"
+			if e := repo.Create().Do().Err(); e != nil || server.Repo("user", "plain") == nil || len(server.Requests()) != before+1 {
+				t.Fatalf("create: %v %v", e, server.Requests()[before:])
+			}
+		})
+	}
+
+	// dry run plans follow-up request
+	server := gitapitest.NewServer(vendor.Github)
+	defer server.Close()
+	property := server.Property("")
+	property.DryRun = true
+	repo := new(api.Repo).New(&property)
+	repo.Info.Name = "svc"
+	repo.Info.AutoInit = true
+	repo.Info.DefaultBranch = "trunk"
+	plan := repo.CreateOrg("org").Do()
+	if plan.Err() != nil || len(plan.Plan().Steps) != 2 || !strings.HasSuffix(plan.Plan().Steps[1].Url, "/repos/org/svc/branches/main/rename") {
+		t.Fatalf("dry run: %v\n%s", plan.Err(), *plan.Output())
+	}
+
+	// follow-up failure reports created repository
+	gitea := gitapitest.NewServer(vendor.Gitea)
+	defer gitea.Close()
+	property = gitea.Property("")
+	property.Transport = roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		if r.Method == http.MethodPatch {
+			return &http.Response{StatusCode: 500, Status: "500 Internal Server Error", Header: http.Header{}, Body: io.NopCloser(strings.NewReader(`{"message":"boom"}`))}, nil
+		}
+		return http.DefaultTransport.RoundTrip(r)
+	})
+	repo = new(api.Repo).New(&property)
+	repo.Info.Name = "svc"
+	repo.Info.Homepage = "https://svc.dev"
+	if e := repo.Create().Do().Err(); e == nil || !strings.Contains(e.Error(), "repository svc created") || !errors.Is(e, base.ErrServer) || gitea.Repo("user", "svc") == nil {
+		t.Fatalf("follow-up failure: %v", e)
+	}
+
+	// gitlab
+	gitlab := base.Property{EntryPoint: server.URL, Vendor: vendor.Gitlab, User: "user"}
+	repo = new(api.Repo).New(&gitlab)
+	repo.Info.Name = "svc"
+	if e := repo.CreateOrg("org").Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("gitlab org: %v", e)
+	}
+	repo.Info.LicenseTemplate = "mit"
+	if e := repo.Create().Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("gitlab template: %v", e)
+	}
+}
@@ -0,0 +1,107 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/credential"
+)
+
+func TestCredential(t *testing.T) {
+	var (
+		ctx = t.Context()
+		dir = t.TempDir()
+	)
+	// Env
+	t.Setenv("GITAPI_TEST_EMPTY", "")
+	t.Setenv("GITAPI_TEST_TOKEN", "env-token")
+	if token, e := (credential.Env{"GITAPI_TEST_EMPTY", "GITAPI_TEST_TOKEN"}).Token(ctx); token != "env-token" {
+		t.Fatalf("env: %s %v", token, e)
+	}
+	if _, e := (credential.Env{"GITAPI_TEST_EMPTY"}).Token(ctx); !errors.Is(e, credential.ErrNotFound) {
+		t.Fatalf("env: %v", e)
+	}
+
+	// File
+	file := filepath.Join(dir, "token")
+	os.WriteFile(file, []byte("file-token\n"), 0o644)
+	if _, e := credential.File(file).Token(ctx); e == nil {
+		t.Fatal("file: open permission should fail")
+	}
+	os.Chmod(file, 0o600)
+	if token, e := credential.File(file).Token(ctx); token != "file-token" {
+		t.Fatalf("file: %s %v", token, e)
+	}
+
+	// Netrc
+	netrc := filepath.Join(dir, "netrc")
+	os.WriteFile(netrc, []byte(`machine gitlab.com login user password gitlab-token
+macdef init
+machine github.com password macro-token
+
+machine github.com
+	login user
+	password github-token
+default login anonymous password default-token
+`), 0o600)
+	for machine, want := range map[string]string{"github.com": "github-token", "gitlab.com": "gitlab-token", "gitea.com": "default-token"} {
+		if token, e := (&credential.Netrc{File: netrc, Machine: machine}).Token(ctx); token != want {
+			t.Fatalf("netrc %s: %s %v", machine, token, e)
+		}
+	}
+
+	// Git credential helper
+	t.Setenv("HOME", dir)
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+	t.Setenv("GIT_CONFIG_COUNT", "1")
+	t.Setenv("GIT_CONFIG_KEY_0", "credential.helper")
+	t.Setenv("GIT_CONFIG_VALUE_0", `!f() { test "$1" = get && echo username=user && echo password=git-token; }; f`)
+	if token, e := (&credential.Git{Url: "https://github.com"}).Token(ctx); token != "git-token" {
+		t.Fatalf("git: %s %v", token, e)
+	}
+
+	// Property.TokenSource
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"description":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer server.Close()
+	property := base.Property{EntryPoint: server.URL, Token: "static", TokenSource: credential.File(file)}
+	description := new(api.Description).New(&property).Get()
+	if !description.Do().Ok() || description.Info.Description != "token file-token" {
+		t.Fatalf("token source: %s %v", description.Info.Description, description.Err())
+	}
+	property.TokenSource = credential.Env{"GITAPI_TEST_EMPTY"}
+	if e := description.Do().Err(); !errors.Is(e, base.ErrAuth) || !errors.Is(e, credential.ErrNotFound) {
+		t.Fatalf("token source: %v", e)
+	}
+}
@@ -0,0 +1,83 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestDetect(t *testing.T) {
+	var tests = []struct {
+		name   string
+		routes map[string]string // path -> body, "401" prefix for unauthorized
+		path   string            // entry point path
+		vendor vendor.Vendor
+		api    string
+	}{
+		{"github enterprise", map[string]string{"/api/v3/meta": `{"installed_version":"3.12.0"}`}, "", vendor.Github, base.ApiPathGithub},
+		{"gitea", map[string]string{"/api/v1/version": `{"version":"1.22.1"}`}, "/api/v1", vendor.Gitea, base.ApiPathGitea},
+		{"forgejo", map[string]string{"/api/v1/version": `{"version":"7.0.5+gitea-1.21.11"}`}, "/", vendor.Forgejo, base.ApiPathGitea},
+		{"forgejo api", map[string]string{"/api/v1/version": `{"version":"1.20.1-0"}`, "/api/forgejo/v1/version": `{"version":"1.20.1-0"}`}, "", vendor.Forgejo, base.ApiPathGitea},
+		{"gitlab", map[string]string{"/api/v4/version": `401{"message":"401 Unauthorized"}`}, "/api/v4", vendor.Gitlab, base.ApiPathGitlab},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, ok := test.routes[r.URL.Path]
+			switch {
+			case !ok:
+				http.NotFound(w, r)
+			case len(body) > 3 && body[:3] == "401":
+				w.WriteHeader(http.StatusUnauthorized)
+				w.Write([]byte(body[3:]))
+			default:
+				w.Write([]byte(body))
+			}
+		}))
+		property := base.Property{EntryPoint: server.URL + test.path, Vendor: vendor.Gogs}
+		e := base.Detect(t.Context(), &property)
+		server.Close()
+		if e != nil || property.Vendor != test.vendor || property.EntryPoint != server.URL+test.api {
+			t.Fatalf("%s: vendor=%s entry point=%s, %v", test.name, property.Vendor, property.EntryPoint, e)
+		}
+	}
+
+	property := base.Property{EntryPoint: "https://github.com/J-Siu"}
+	if e := base.Detect(t.Context(), &property); e != nil || property.Vendor != vendor.Github || property.EntryPoint != "https://api.github.com" {
+		t.Fatalf("github: vendor=%s entry point=%s, %v", property.Vendor, property.EntryPoint, e)
+	}
+
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+	property = base.Property{EntryPoint: server.URL}
+	if e := base.Detect(t.Context(), &property); !errors.Is(e, base.ErrDetect) {
+		t.Fatalf("unknown server: %v", e)
+	}
+}
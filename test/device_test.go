@@ -0,0 +1,127 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/credential"
+)
+
+// Fake authorization server, token endpoint answers with replies in order
+func deviceServer(t *testing.T, replies ...string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		r.ParseForm()
+		if r.Form.Get("client_id") != "client" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":"invalid_client"}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/login/device/code", "/login/oauth/device/code":
+			json.NewEncoder(w).Encode(credential.DeviceCode{DeviceCode: "device", UserCode: "ABCD-1234", VerificationUri: "http://verify", ExpiresIn: 60})
+		case "/login/oauth/access_token":
+			if r.Form.Get("grant_type") != credential.DeviceGrantType || r.Form.Get("device_code") != "device" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":"invalid_grant"}`))
+				return
+			}
+			reply := replies[0]
+			if len(replies) > 1 {
+				replies = replies[1:]
+			}
+			if reply != `{"access_token":"oauth-token","token_type":"bearer"}` {
+				w.WriteHeader(http.StatusBadRequest) // rfc 8628 style, github uses 200
+			}
+			w.Write([]byte(reply))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestDevice(t *testing.T) {
+	var (
+		ok      = `{"access_token":"oauth-token","token_type":"bearer"}`
+		pending = `{"error":"authorization_pending"}`
+	)
+	for _, test := range []struct {
+		name    string
+		replies []string
+		err     error
+	}{
+		{"token", []string{pending, `{"error":"slow_down"}`, ok}, nil},
+		{"expired", []string{pending, `{"error":"expired_token"}`}, credential.ErrDeviceExpired},
+		{"denied", []string{`{"error":"access_denied"}`}, credential.ErrDeviceDenied},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			server := deviceServer(t, test.replies...)
+			device := credential.DeviceGitea(server.URL+"/", "client", "repo")
+			device.Interval = 10 * time.Millisecond
+			var prompt *credential.DeviceCode
+			device.Prompt = func(code *credential.DeviceCode) error { prompt = code; return nil }
+
+			token, e := device.Token(t.Context())
+			if !errors.Is(e, test.err) {
+				t.Fatalf("error: %v", e)
+			}
+			if prompt == nil || prompt.UserCode != "ABCD-1234" {
+				t.Fatalf("prompt: %v", prompt)
+			}
+			if test.err == nil && token != "oauth-token" {
+				t.Fatalf("token: %s", token)
+			}
+		})
+	}
+
+	// github preset, cached token
+	server := deviceServer(t, `{"access_token":"oauth-token","token_type":"bearer"}`)
+	device := credential.DeviceGithub("client")
+	device.DeviceCodeUrl = server.URL + "/login/device/code"
+	device.TokenUrl = server.URL + "/login/oauth/access_token"
+	device.Interval = time.Millisecond
+	prompts := 0
+	device.Prompt = func(code *credential.DeviceCode) error { prompts++; return nil }
+	for range 2 {
+		if token, e := device.Token(t.Context()); token != "oauth-token" || e != nil {
+			t.Fatalf("github: %s %v", token, e)
+		}
+	}
+	if prompts != 1 {
+		t.Fatalf("prompts: %d", prompts)
+	}
+
+	// bad client
+	if _, e := (&credential.Device{ClientId: "other", DeviceCodeUrl: device.DeviceCodeUrl, Prompt: device.Prompt}).Login(t.Context()); e == nil {
+		t.Fatal("bad client should fail")
+	}
+}
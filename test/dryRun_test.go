@@ -0,0 +1,86 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestDryRun(t *testing.T) {
+	server := gitapitest.NewServer(vendor.Github)
+	defer server.Close()
+	server.AddRepo("repo", false)
+	property := server.Property("repo")
+	property.DryRun = true
+
+	// steps: method url (note), body
+	check := func(name string, b *base.Base, want ...string) {
+		t.Helper()
+		if !b.Ok() || b.Err() != nil || b.Plan() == nil {
+			t.Fatalf("%s: %v %v", name, b.Ok(), b.Err())
+		}
+		var got []string
+		for _, step := range b.Plan().Steps {
+			got = append(got, strings.TrimPrefix(step.Method+" "+step.Url+" "+step.Note+" "+step.Body, " "))
+			if step.Header.Get("Authorization") != "token REDACTED" {
+				t.Fatalf("%s: header %v", name, step.Header)
+			}
+		}
+		if strings.Join(got, "\n") != strings.Join(want, "\n") {
+			t.Fatalf("%s:\n%s", name, strings.Join(got, "\n"))
+		}
+		if *b.Output() != b.Plan().String() {
+			t.Fatalf("%s: output %s", name, *b.Output())
+		}
+	}
+	url := server.URL + "/repos/user/repo"
+	check("del", new(api.Repo).New(&property).Del().Do(), "DELETE "+url+"  ")
+	check("visibility", new(api.Visibility).New(&property).Set(false).Do(), "PATCH "+url+"  "+`{"visibility":"private"}`)
+	check("archived", new(api.Archived).New(&property).Set(true).Do(), "PATCH "+url+"  "+`{"archived":true}`)
+	check("secret", new(api.EncryptedPair).New(&property).Set("TOKEN", "top-secret").Do(),
+		"GET "+url+"/actions/secrets/public-key  ",
+		"PUT "+url+"/actions/secrets/TOKEN  "+`{"encrypted_value":"REDACTED"}`)
+
+	gitlab := property
+	gitlab.EntryPoint = server.URL
+	gitlab.Vendor = vendor.Gitlab
+	gitlab.AuthScheme = base.AuthToken
+	check("gitlab variable", new(api.EncryptedPair).New(&gitlab).Set("TOKEN", "top-secret").Do(),
+		"PUT "+server.URL+"/projects/user%2Frepo/variables/TOKEN  "+`{"value":"REDACTED"}`,
+		"POST "+server.URL+"/projects/user%2Frepo/variables if previous step returns 404 "+`{"key":"TOKEN","value":"REDACTED"}`)
+
+	if requests := server.Requests(); len(requests) != 0 {
+		t.Fatalf("requests sent: %v", requests)
+	}
+	if server.Repo("user", "repo") == nil {
+		t.Fatal("repo deleted")
+	}
+}
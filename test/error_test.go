@@ -0,0 +1,87 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestErrorModel(t *testing.T) {
+	var tests = []struct {
+		name     string
+		status   int
+		header   map[string]string
+		body     string
+		sentinel error
+		message  string
+		fields   int
+	}{
+		{"github validation", 422, nil, `{"message":"Validation Failed","errors":[{"resource":"Repository","code":"custom","field":"name","message":"name already exists on this account"}],"documentation_url":"https://docs.github.com/rest"}`, base.ErrValidation, "Validation Failed", 1},
+		{"gitea validation", 422, nil, `{"message":"[Name]: Required","errors":["Name is required"],"url":"https://gitea.example/api/swagger"}`, base.ErrValidation, "[Name]: Required", 1},
+		{"not found", 404, nil, `{"message":"Not Found"}`, base.ErrNotFound, "Not Found", 0},
+		{"unauthorized", 401, nil, `{"message":"Bad credentials"}`, base.ErrUnauthorized, "Bad credentials", 0},
+		{"rate limited", 403, map[string]string{"X-RateLimit-Limit": "5000", "X-RateLimit-Remaining": "0"}, `{"message":"API rate limit exceeded"}`, base.ErrRateLimited, "API rate limit exceeded", 0},
+		{"server", 502, nil, ``, base.ErrServer, "", 0},
+		{"gitlab fields", 400, nil, `{"message":{"name":["has already been taken"],"path":["has already been taken","is too long"]}}`, base.ErrBadRequest, "", 2},
+		{"gitlab list", 400, nil, `{"message":["invalid","missing"]}`, base.ErrBadRequest, "invalid, missing", 0},
+	}
+	for _, test := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for k, v := range test.header {
+				w.Header().Set(k, v)
+			}
+			w.WriteHeader(test.status)
+			w.Write([]byte(test.body))
+		}))
+		property := base.Property{EntryPoint: server.URL, User: "user", Repo: "repo", Vendor: vendor.Github, AllowDestructive: true}
+		visibility := new(api.Visibility).New(&property).Set(true)
+		visibility.Do()
+		server.Close()
+
+		var e *base.Error
+		err := visibility.Err()
+		switch {
+		case visibility.Ok():
+			t.Fatalf("%s: should fail", test.name)
+		case !errors.Is(err, test.sentinel):
+			t.Fatalf("%s: %v is not %v", test.name, err, test.sentinel)
+		case !errors.As(err, &e):
+			t.Fatalf("%s: %v is not *base.Error", test.name, err)
+		case e.Status != test.status || e.Message != test.message || len(e.Errors) != test.fields:
+			t.Fatalf("%s: unexpected %#v", test.name, e)
+		case test.name == "gitlab fields" && e.Error() != "PATCH repos/user/repo: 400 bad request (name: has already been taken, path: has already been taken, is too long)":
+			t.Fatalf("%s: unexpected message %s", test.name, e)
+		case e.Method != http.MethodPatch || e.Endpoint != "repos/user/repo" || e.Vendor != vendor.Github:
+			t.Fatalf("%s: unexpected request info %#v", test.name, e)
+		}
+	}
+}
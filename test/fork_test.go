@@ -0,0 +1,135 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestFork(t *testing.T) {
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			upstream := server.AddRepo("lib", false)
+			upstream.PushedAt = time.Now().Add(-time.Hour)
+			server.SetRepo(upstream)
+			property := server.Property("lib")
+
+			fork := new(api.Fork).New(&property)
+			if e := fork.Create("org", "", false).Do().Err(); e != nil {
+				t.Fatal(e)
+			}
+			if fork.Info.Repository.FullName != "org/lib" || !fork.Info.Repository.Fork {
+				t.Fatalf("create: %+v", fork.Info.Repository)
+			}
+			if e := fork.Create("", "lib-fork", false).Do().Err(); e != nil || server.Repo("user", "lib-fork") == nil {
+				t.Fatalf("create named: %v", e)
+			}
+			if e := fork.Create("org", "", false).Do().Err(); e == nil {
+				t.Fatal("fork exists")
+			}
+			e := fork.Create("org2", "", true).Do().Err()
+			if v == vendor.Github && e != nil || v != vendor.Github && !errors.Is(e, base.ErrUnsupported) {
+				t.Fatalf("default branch only: %v", e)
+			}
+
+			// list, 1 per page
+			fork.Get(1)
+			fork.Req.UrlVal.Set("per_page", "1")
+			fork.Req.UrlVal.Set("limit", "1")
+			var names []string
+			for repo, e := range fork.Iter(context.Background()) {
+				if e != nil {
+					t.Fatal(e)
+				}
+				names = append(names, repo.FullName)
+			}
+			want := []string{"org/lib", "user/lib-fork"}
+			if v == vendor.Github {
+				want = []string{"org/lib", "org2/lib", "user/lib-fork"}
+			}
+			if !slices.Equal(names, want) {
+				t.Fatalf("list: %v", names)
+			}
+
+			// iterate after create, and on new fork
+			for _, fork := range []*api.Fork{fork.Create("org3", "", false), new(api.Fork).New(&property)} {
+				names = nil
+				for repo, e := range fork.Iter(context.Background()) {
+					if e != nil {
+						t.Fatal(e)
+					}
+					names = append(names, repo.FullName)
+				}
+				if !slices.Equal(names, want) {
+					t.Fatalf("iter: %v", names)
+				}
+			}
+
+			// sync
+			upstream.PushedAt = time.Now()
+			server.SetRepo(upstream)
+			property.Repo = "lib-fork"
+			if e := fork.Sync("main").Do().Err(); e != nil || fork.Merge.MergeType != "fast-forward" {
+				t.Fatalf("sync: %v %+v", e, fork.Merge)
+			}
+			if e := fork.Sync("main").Do().Err(); e != nil || fork.Merge.MergeType != "none" || fork.Merge.Branch != "main" {
+				t.Fatalf("sync again: %v %+v", e, fork.Merge)
+			}
+			if e := fork.Sync("dev").Do().Err(); !errors.Is(e, base.ErrNotFound) {
+				t.Fatalf("sync branch: %v", e)
+			}
+		})
+	}
+
+	// capability
+	property := base.Property{EntryPoint: "http://localhost", Vendor: vendor.Gitea, User: "user", Repo: "lib", Capability: base.NewCapability(vendor.Gitea, "1.22.0")}
+	if e := new(api.Fork).New(&property).Sync("main").Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("gitea 1.22: %v", e)
+	}
+	for _, v := range []vendor.Vendor{vendor.Forgejo, vendor.Gitlab} {
+		property := base.Property{EntryPoint: "http://localhost", Vendor: v, User: "user", Repo: "lib"}
+		if e := new(api.Fork).New(&property).Sync("main").Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+			t.Fatalf("%s: %v", v, e)
+		}
+	}
+
+	// gitlab create
+	gitlab := base.Property{EntryPoint: "http://localhost", Vendor: vendor.Gitlab, User: "user", Repo: "lib", DryRun: true}
+	plan := new(api.Fork).New(&gitlab).Create("group", "lib-fork", false).Do()
+	if plan.Err() != nil || plan.Plan().Steps[0].Body != `{"name":"lib-fork","namespace_path":"group","path":"lib-fork"}` || plan.Plan().Steps[0].Endpoint != "projects/user%2Flib/fork" {
+		t.Fatalf("gitlab: %v\n%s", plan.Err(), *plan.Output())
+	}
+}
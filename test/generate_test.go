@@ -0,0 +1,119 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestRepoGenerate(t *testing.T) {
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			template := server.AddRepo("tmpl", false)
+			template.DefaultBranch = "trunk"
+			template.Topics = []string{"go"}
+			server.SetRepo(template)
+			property := server.Property("")
+
+			// not a template
+			repo := new(api.Repo).New(&property)
+			repo.Template.Name = "svc"
+			repo.Template.GitContent = true
+			if e := repo.Generate("user", "tmpl").Do().Err(); e == nil {
+				t.Fatal("generated from non-template")
+			}
+
+			template.Template = true
+			server.SetRepo(template)
+			repo = new(api.Repo).New(&property)
+			repo.Template.Owner = "org"
+			repo.Template.Name = "svc"
+			repo.Template.Description = "service"
+			repo.Template.Private = true
+			repo.Template.GitContent = true
+			repo.Template.Topics = true
+			if e := repo.Generate("user", "tmpl").Do().Err(); e != nil {
+				t.Fatal(e)
+			}
+			if repo.Template.Repository.FullName != "org/svc" || repo.Template.Repository.DefaultBranch != "trunk" {
+				t.Fatalf("result: %+v", repo.Template.Repository)
+			}
+			got := server.Repo("org", "svc")
+			if got == nil {
+				t.Fatalf("not generated: %v", server.Requests())
+			}
+			topics := len(got.Topics) == 1
+			if s := fmt.Sprint(got.Private, got.Description, topics); s != fmt.Sprint(true, "service", v == vendor.Gitea) {
+				t.Fatalf("state: %s", s)
+			}
+
+			// owner defaults to Property.User
+			repo = new(api.Repo).New(&property)
+			repo.Template.Name = "svc"
+			repo.Template.GitContent = true
+			if e := repo.Generate("user", "tmpl").Do().Err(); e != nil || server.Repo("user", "svc") == nil {
+				t.Fatalf("user: %v", e)
+			}
+		})
+	}
+
+	// gitea requires template item
+	server := gitapitest.NewServer(vendor.Gitea)
+	defer server.Close()
+	template := server.AddRepo("tmpl", false)
+	template.Template = true
+	server.SetRepo(template)
+	property := server.Property("")
+	repo := new(api.Repo).New(&property)
+	repo.Template.Name = "svc"
+	if e := repo.Generate("user", "tmpl").Do().Err(); e == nil {
+		t.Fatal("gitea without template item")
+	}
+
+	// dry run
+	property.DryRun = true
+	repo.Template.Labels = true
+	plan := repo.Generate("user", "tmpl").Do()
+	if plan.Err() != nil || len(plan.Plan().Steps) != 1 || string(plan.Plan().Steps[0].Body) != `{"labels":true,"name":"svc","owner":"user","private":false}` {
+		t.Fatalf("dry run: %v\n%s", plan.Err(), *plan.Output())
+	}
+
+	// gitlab
+	gitlab := base.Property{EntryPoint: server.URL, Vendor: vendor.Gitlab, User: "user"}
+	repo = new(api.Repo).New(&gitlab)
+	repo.Template.Name = "svc"
+	if e := repo.Generate("user", "tmpl").Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("gitlab: %v", e)
+	}
+}
@@ -0,0 +1,115 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestGitapitest(t *testing.T) {
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea, vendor.Forgejo} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			property := server.Property("repo")
+			property.AllowDestructive = true
+			check := func(b *base.Base) {
+				t.Helper()
+				if !b.Ok() {
+					t.Fatalf("%s %s: %v", b.Method, b.Req.Endpoint, b.Err())
+				}
+			}
+
+			// create, duplicate
+			repo := new(api.Repo).New(&property)
+			repo.Info.Name = "repo"
+			repo.Info.Private = true
+			check(repo.Create().Do())
+			if e := repo.Do().Err(); !errors.Is(e, base.ErrConflict) && !errors.Is(e, base.ErrValidation) {
+				t.Fatalf("duplicate: %v", e)
+			}
+
+			check(new(api.Description).New(&property).Set("desc").Do())
+			check(new(api.Private).New(&property).Set(false).Do())
+			check(new(api.Wiki).New(&property).Set(false).Do())
+			check(new(api.Archived).New(&property).Set(true).Do())
+			check(new(api.Actions).New(&property).Set(false).Do())
+			topics := new(api.Topics).New(&property)
+			topics.Info.Names = &[]string{"go", "api"}
+			topics.Info.Topics = topics.Info.Names
+			check(topics.Set().Do())
+			check(new(api.EncryptedPair).New(&property).Set("TOKEN", "secret").Do())
+
+			got := server.Repo("user", "repo")
+			want := fmt.Sprint("desc", false, false, true, false, []string{"go", "api"}, "secret")
+			if s := fmt.Sprint(got.Description, got.Private, got.HasWiki, got.Archived, got.HasActions, got.Topics, got.Secrets["TOKEN"]); s != want {
+				t.Fatalf("state: %s, want %s", s, want)
+			}
+
+			// get
+			description := new(api.Description).New(&property).Get()
+			check(description.Do())
+			topics = new(api.Topics).New(&property).Get()
+			check(topics.Do())
+			if description.Info.Description != "desc" || topics.Info.String() != "go,api" {
+				t.Fatalf("get: %s %s", description.Info.Description, topics.Info.String())
+			}
+
+			// delete secret, repo
+			check(new(api.Repo).New(&property).DelSecret("TOKEN").Do())
+			check(new(api.Repo).New(&property).Del().Do())
+			if e := new(api.Info).New(&property).Get().Do().Err(); !errors.Is(e, base.ErrNotFound) {
+				t.Fatalf("deleted: %v", e)
+			}
+
+			// pagination
+			for i := range 120 {
+				server.AddRepo(fmt.Sprintf("repo%03d", i), i%2 == 0)
+			}
+			list := new(api.InfoList).New(&property, 1)
+			check(list.DoAll())
+			if len(list.Info) != 120 || list.Info[119].Name != "repo119" {
+				t.Fatalf("list: %d", len(list.Info))
+			}
+
+			// token
+			property.Token = "wrong"
+			if e := new(api.Info).New(&property).Get().Do().Err(); !errors.Is(e, base.ErrUnauthorized) {
+				t.Fatalf("token: %v", e)
+			}
+			if !slices.Contains(server.Requests(), "GET /repos/user/repo") {
+				t.Fatalf("requests: %v", server.Requests())
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+func TestGithubApp(t *testing.T) {
+	key, e := rsa.GenerateKey(rand.Reader, 2048)
+	if e != nil {
+		t.Fatal(e)
+	}
+	var (
+		exchange  int
+		expiresIn = time.Hour
+		pemKey    = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/app/installations/42/access_tokens" {
+			// Verify JWT
+			jwt := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+			signature, _ := base64.RawURLEncoding.DecodeString(jwt[2])
+			hash := sha256.Sum256([]byte(jwt[0] + "." + jwt[1]))
+			if r.Method != http.MethodPost || rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature) != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			var claims struct {
+				Iss string `json:"iss"`
+			}
+			payload, _ := base64.RawURLEncoding.DecodeString(jwt[1])
+			if json.Unmarshal(payload, &claims); claims.Iss != "7" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			exchange++
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(map[string]any{
+				"token":      "ghs_" + string(rune('0'+exchange)),
+				"expires_at": time.Now().Add(expiresIn).UTC().Format(time.RFC3339),
+			})
+			return
+		}
+		w.Write([]byte(`{"description":"` + r.Header.Get("Authorization") + `"}`))
+	}))
+	defer server.Close()
+
+	property := base.Property{
+		EntryPoint:        server.URL,
+		AppId:             7,
+		AppInstallationId: 42,
+		AppPrivateKey:     pemKey,
+		User:              "user",
+		Repo:              "repo",
+	}
+	for range 2 {
+		description := new(api.Description).New(&property).Get()
+		if !description.Do().Ok() || description.Info.Description != "token ghs_1" || exchange != 1 {
+			t.Fatalf("cached token: exchange=%d %s %v", exchange, description.Info.Description, description.Err())
+		}
+	}
+
+	// Token about to expire is refreshed, different entry point for a new cache entry
+	property.EntryPoint = server.URL + "/"
+	expiresIn = time.Minute
+	for _, want := range []string{"token ghs_2", "token ghs_3"} {
+		description := new(api.Description).New(&property).Get()
+		if !description.Do().Ok() || description.Info.Description != want {
+			t.Fatalf("refresh token: exchange=%d %s %v", exchange, description.Info.Description, description.Err())
+		}
+	}
+
+	// Refresh uses http options of calling property, not of the one creating cache entry
+	var endpoints []string
+	other := property
+	other.Hooks = []base.Hooks{{After: func(ctx context.Context, event *base.Event) { endpoints = append(endpoints, event.Endpoint) }}}
+	if e := new(api.Description).New(&other).Get().Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	if strings.Join(endpoints, ",") != "app/installations/42/access_tokens,repos/user/repo" {
+		t.Fatalf("hooks of calling property: %v", endpoints)
+	}
+}
@@ -0,0 +1,109 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestGitlab(t *testing.T) {
+	var (
+		request string // last request: method, escaped path, body
+		header  http.Header
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		request = r.Method + " " + r.URL.EscapedPath() + " " + string(body)
+		header = r.Header
+		if r.Method == http.MethodPut && r.URL.EscapedPath() == "/api/v4/projects/user%2Frepo/variables/NEW" {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"404 Variable Not Found"}`))
+			return
+		}
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var (
+		property = base.Property{
+			AllowDestructive: true,
+			EntryPoint:       server.URL + "/api/v4",
+			Repo:             "repo",
+			Token:            "secret",
+			User:             "user",
+			Vendor:           vendor.Gitlab,
+		}
+		repo = new(api.Repo).New(&property)
+	)
+	repo.Info.Name = "repo"
+	repo.Info.Private = true
+	internal := new(api.Repo).New(&property)
+	internal.Info.Name = "repo"
+	internal.Info.Visibility = "internal"
+
+	var tests = []struct {
+		name string
+		api  api.IApi
+		want string
+	}{
+		{"create", repo.Create(), `POST /api/v4/projects {"name":"repo","visibility":"private"}`},
+		{"create internal", internal.Create(), `POST /api/v4/projects {"name":"repo","visibility":"internal"}`},
+		{"visibility", new(api.Visibility).New(&property).Set(true), `PUT /api/v4/projects/user%2Frepo {"visibility":"public"}`},
+		{"description", new(api.Description).New(&property).Set("test"), `PUT /api/v4/projects/user%2Frepo {"description":"test"}`},
+		{"archive", new(api.Archived).New(&property).Set(true), `POST /api/v4/projects/user%2Frepo/archive {"archived":true}`},
+		{"unarchive", new(api.Archived).New(&property).Set(false), `POST /api/v4/projects/user%2Frepo/unarchive {"archived":false}`},
+		{"wiki", new(api.Wiki).New(&property).Set(true), `PUT /api/v4/projects/user%2Frepo {"wiki_enabled":true}`},
+		{"topics", new(api.Topics).New(&property).Get(), `GET /api/v4/projects/user%2Frepo `},
+		{"variable update", new(api.EncryptedPair).New(&property).Set("OLD", "value"), `PUT /api/v4/projects/user%2Frepo/variables/OLD {"value":"value"}`},
+		{"variable create", new(api.EncryptedPair).New(&property).Set("NEW", "value"), `POST /api/v4/projects/user%2Frepo/variables {"key":"NEW","value":"value"}`},
+		{"delete variable", new(api.Repo).New(&property).DelSecret("OLD"), `DELETE /api/v4/projects/user%2Frepo/variables/OLD `},
+		{"delete", new(api.Repo).New(&property).Del(), `DELETE /api/v4/projects/user%2Frepo `},
+	}
+	for _, test := range tests {
+		if !test.api.Do().Ok() {
+			t.Fatalf("%s: %v", test.name, test.api.Err())
+		}
+		if request != test.want {
+			t.Fatalf("%s:\n got: %s\nwant: %s", test.name, request, test.want)
+		}
+		if header.Get("PRIVATE-TOKEN") != "secret" {
+			t.Fatalf("%s: PRIVATE-TOKEN not set", test.name)
+		}
+	}
+
+	request = ""
+	actions := new(api.Actions).New(&property).Get()
+	if actions.Do().Ok() || !errors.Is(actions.Err(), base.ErrUnsupported) || request != "" {
+		t.Fatalf("actions should be unsupported: %v", actions.Err())
+	}
+}
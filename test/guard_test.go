@@ -0,0 +1,130 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestGuard(t *testing.T) {
+	server := gitapitest.NewServer(vendor.Github)
+	defer server.Close()
+	server.AddRepo("repo", true)
+	server.AddRepo("prod-api", true)
+	property := server.Property("repo")
+	refused := func(name string, b *base.Base) {
+		t.Helper()
+		if e := b.Err(); !errors.Is(e, base.ErrDestructive) {
+			t.Fatalf("%s: %v", name, e)
+		}
+	}
+
+	// not allowed by default
+	refused("del", new(api.Repo).New(&property).Del().Do())
+	refused("make public", new(api.Visibility).New(&property).Set(true).Do())
+	refused("private false", new(api.Private).New(&property).Set(false).Do())
+	refused("archive", new(api.Archived).New(&property).Set(true).Do())
+	if slices.ContainsFunc(server.Requests(), func(s string) bool { return !strings.HasPrefix(s, "GET") }) {
+		t.Fatalf("requests sent: %v", server.Requests())
+	}
+	// not destructive
+	for _, b := range []*base.Base{
+		new(api.Visibility).New(&property).Set(false).Do(),
+		new(api.Archived).New(&property).Set(true).Get().Do(),
+		new(api.Archived).New(&property).Set(false).Do(),
+	} {
+		if e := b.Err(); e != nil {
+			t.Fatal(e)
+		}
+	}
+
+	// confirm, allow list
+	var confirmed []string
+	confirm := true
+	property.Guard = &base.Guard{
+		Allow: []base.Operation{base.OpArchive},
+		Confirm: func(ctx context.Context, op base.Operation, target string) bool {
+			confirmed = append(confirmed, string(op)+" "+target)
+			return confirm
+		},
+	}
+	if e := new(api.Archived).New(&property).Set(true).Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	secret := new(api.EncryptedPair).New(&property).Set("TOKEN", "secret")
+	if e := secret.Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	if e := new(api.Repo).New(&property).DelSecret("TOKEN").Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	confirm = false
+	refused("not confirmed", new(api.Visibility).New(&property).Set(true).Do())
+	if strings.Join(confirmed, ",") != "delete_secret user/repo/TOKEN,make_public user/repo" {
+		t.Fatalf("confirm: %v", confirmed)
+	}
+
+	// protected, recent push, regardless of AllowDestructive
+	property.AllowDestructive = true
+	property.Guard = &base.Guard{Protected: []string{"user/prod-*"}, RecentPush: time.Hour}
+	prod := property
+	prod.Repo = "prod-api"
+	refused("protected", new(api.Repo).New(&prod).Del().Do())
+	repo := server.Repo("user", "repo")
+	repo.PushedAt = time.Now().Add(-time.Minute)
+	server.SetRepo(repo)
+	refused("recent push", new(api.Repo).New(&property).Del().Do())
+	// failed push time lookup keeps both errors
+	missing := property
+	missing.Repo = "missing"
+	if e := new(api.Repo).New(&missing).Del().Do().Err(); !errors.Is(e, base.ErrDestructive) || !errors.Is(e, base.ErrNotFound) {
+		t.Fatalf("lookup failure: %v", e)
+	}
+	repo.PushedAt = time.Now().Add(-2 * time.Hour)
+	server.SetRepo(repo)
+	if e := new(api.Repo).New(&property).Del().Do().Err(); e != nil || server.Repo("user", "repo") != nil {
+		t.Fatalf("delete: %v", e)
+	}
+	if server.Repo("user", "prod-api") == nil {
+		t.Fatal("protected repo deleted")
+	}
+
+	// dry run reports operation without guard
+	prod.DryRun = true
+	del := new(api.Repo).New(&prod).Del().Do()
+	if del.Err() != nil || del.Plan().Steps[0].Destructive != base.OpDeleteRepo || !strings.Contains(*del.Output(), "[delete_repo]") {
+		t.Fatalf("dry run: %v %v", del.Err(), *del.Output())
+	}
+}
@@ -0,0 +1,126 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+type spanKey struct{}
+
+func TestHooks(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/missing/actions/secrets/TOKEN"):
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"Not Found"}`))
+		case calls == 1:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`{"description":"desc"}`))
+		}
+	}))
+	defer server.Close()
+
+	var (
+		log     []string
+		metrics = map[string]int{}
+		spans   []string
+	)
+	property := base.Property{
+		EntryPoint:  server.URL,
+		Repo:        "repo",
+		Token:       "ghp_hook",
+		User:        "user",
+		Vendor:      vendor.Gitea,
+		RetryPolicy: &base.RetryPolicy{MaxAttempts: 2, Backoff: time.Millisecond},
+		Hooks: []base.Hooks{{
+			Before: func(ctx context.Context, event *base.Event) context.Context {
+				log = append(log, fmt.Sprint(event.Attempt, " ", event.Method, " ", event.Endpoint, " ", event.Header.Get("Authorization"), " ", string(event.Body)))
+				return context.WithValue(ctx, spanKey{}, event.Attempt)
+			},
+			After: func(ctx context.Context, event *base.Event) {
+				metrics[fmt.Sprint(event.Vendor, " ", event.Method, " ", event.Endpoint, " ", event.StatusCode)]++
+			},
+		}, {
+			OnError: func(ctx context.Context, event *base.Event) {
+				spans = append(spans, fmt.Sprint(ctx.Value(spanKey{}), " ", errors.Is(event.Err, base.ErrServer) || errors.Is(event.Err, base.ErrNotFound)))
+			},
+		}},
+		Middleware: []base.Middleware{func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				if r.Context().Value(spanKey{}) == nil {
+					return nil, errors.New("context from Before hook not used")
+				}
+				return next.RoundTrip(r)
+			})
+		}},
+	}
+
+	// retried get
+	if e := new(api.Description).New(&property).Get().Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	// failed secret, value redacted
+	repo := property
+	repo.Repo = "missing"
+	if e := new(api.EncryptedPair).New(&repo).Set("TOKEN", "top-secret").Do().Err(); !errors.Is(e, base.ErrNotFound) {
+		t.Fatal(e)
+	}
+
+	wantLog := []string{
+		"1 GET repos/user/repo token REDACTED ",
+		"2 GET repos/user/repo token REDACTED ",
+		`1 PUT repos/user/missing/actions/secrets/TOKEN token REDACTED {"data":"REDACTED"}`,
+	}
+	if strings.Join(log, "\n") != strings.Join(wantLog, "\n") {
+		t.Fatalf("log:\n%s", strings.Join(log, "\n"))
+	}
+	for k, v := range map[string]int{
+		"Gitea GET repos/user/repo 503":                          1,
+		"Gitea GET repos/user/repo 200":                          1,
+		"Gitea PUT repos/user/missing/actions/secrets/TOKEN 404": 1,
+	} {
+		if metrics[k] != v {
+			t.Fatalf("metrics: %v", metrics)
+		}
+	}
+	if strings.Join(spans, ",") != "1 true,1 true" {
+		t.Fatalf("errors: %v", spans)
+	}
+}
@@ -0,0 +1,123 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+// Serve total repositories, perPage per page, using header set by header()
+func pageServer(total, perPage int, header func(w http.ResponseWriter, r *http.Request, page, last int)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		last := (total + perPage - 1) / perPage
+		header(w, r, page, last)
+		var body string
+		for i := (page - 1) * perPage; i < page*perPage && i < total; i++ {
+			if body != "" {
+				body += ","
+			}
+			body += fmt.Sprintf(`{"name":"repo%d","topics":["topic%d"],"permissions":{"admin":%v}}`, i, i, i%2 == 0)
+		}
+		w.Write([]byte("[" + body + "]"))
+	}))
+}
+
+func TestInfoListDoAll(t *testing.T) {
+	var tests = map[string]func(w http.ResponseWriter, r *http.Request, page, last int){
+		"link": func(w http.ResponseWriter, r *http.Request, page, last int) {
+			if page < last {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d>; rel="next", <http://%s%s?page=%d>; rel="last"`, r.Host, r.URL.Path, page+1, r.Host, r.URL.Path, last))
+			} else {
+				w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=1>; rel="first"`, r.Host, r.URL.Path))
+			}
+		},
+		"total": func(w http.ResponseWriter, r *http.Request, page, last int) {
+			w.Header().Set("X-Total-Count", "250")
+		},
+		"none": func(w http.ResponseWriter, r *http.Request, page, last int) {},
+	}
+	for name, header := range tests {
+		server := pageServer(250, 30, header)
+		property := base.Property{EntryPoint: server.URL}
+		list := new(api.InfoList).New(&property, 1)
+		ok := list.DoAll().Ok()
+		server.Close()
+		// "none" cannot detect short page as server per page(30) is lower than requested(100)
+		want := 250
+		if name == "none" {
+			want = 30
+		}
+		if !ok || len(list.Info) != want {
+			t.Fatalf("%s: ok=%v count=%d, want %d", name, ok, len(list.Info), want)
+		}
+		for i, repo := range list.Info {
+			if repo.Name != fmt.Sprintf("repo%d", i) || len(repo.Topics) != 1 || repo.Topics[0] != fmt.Sprintf("topic%d", i) || repo.Permissions == nil || repo.Permissions.Admin != (i%2 == 0) {
+				t.Fatalf("%s: item %d: %+v", name, i, repo)
+			}
+		}
+	}
+}
+
+func TestInfoListDoAllFromPage(t *testing.T) {
+	var requests int
+	server := pageServer(250, 100, func(w http.ResponseWriter, r *http.Request, page, last int) {
+		requests++
+		w.Header().Set("X-Total-Count", "250")
+	})
+	defer server.Close()
+	property := base.Property{EntryPoint: server.URL}
+	list := new(api.InfoList).New(&property, 2)
+	if !list.DoAll().Ok() || len(list.Info) != 150 || list.Info[0].Name != "repo100" || requests != 2 {
+		t.Fatalf("ok=%v count=%d requests=%d err=%v", list.Ok(), len(list.Info), requests, list.Err())
+	}
+}
+
+func TestInfoListIterStop(t *testing.T) {
+	server := pageServer(250, 100, func(w http.ResponseWriter, r *http.Request, page, last int) {})
+	defer server.Close()
+	var (
+		count    int
+		property = base.Property{EntryPoint: server.URL}
+	)
+	for _, e := range new(api.InfoList).New(&property, 1).Iter(t.Context()) {
+		if e != nil {
+			t.Fatal(e)
+		}
+		if count++; count == 150 {
+			break
+		}
+	}
+	if count != 150 {
+		t.Fatalf("count=%d", count)
+	}
+}
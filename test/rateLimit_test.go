@@ -0,0 +1,82 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+func TestRateLimitWait(t *testing.T) {
+	var count int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Used", "5000")
+		if count == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"API rate limit exceeded"}`))
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Write([]byte(`{"archived":true}`))
+	}))
+	defer server.Close()
+
+	// No wait on 1 second "Retry-After"
+	property := base.Property{
+		EntryPoint:      server.URL,
+		RateLimitPolicy: &base.RateLimitPolicy{Wait: true, MaxWait: time.Millisecond},
+	}
+	archived := new(api.Archived).New(&property).Get()
+	if archived.Do().Ok() || !errors.Is(archived.Err(), base.ErrRateLimited) || count != 1 {
+		t.Fatalf("should fail without retry: count=%d err=%v", count, archived.Err())
+	}
+	if r := archived.RateLimit(); r == nil || r.Limit != 5000 || r.Remaining != 0 || r.RetryAfter != time.Second {
+		t.Fatalf("unexpected rate limit %#v", r)
+	}
+
+	// Wait and retry
+	count = 0
+	property.RateLimitPolicy.MaxWait = 0
+	start := time.Now()
+	if !archived.Do().Ok() || count != 2 || !archived.Info.Archived {
+		t.Fatalf("should success after retry: count=%d err=%v", count, archived.Err())
+	}
+	if time.Since(start) < time.Second {
+		t.Fatal("retry before Retry-After")
+	}
+	if r := archived.RateLimit(); r == nil || r.Remaining != 4999 {
+		t.Fatalf("unexpected rate limit %#v", r)
+	}
+}
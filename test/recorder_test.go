@@ -0,0 +1,109 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/recorder"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+// Create repo, set topics and secret, get description
+func recorderFlow(property *base.Property, description string) error {
+	repo := new(api.Repo).New(property)
+	repo.Info.Name = "repo"
+	if e := repo.Create().Do().Err(); e != nil {
+		return e
+	}
+	topics := new(api.Topics).New(property)
+	topics.Info.Names = &[]string{"go"}
+	if e := topics.Set().Do().Err(); e != nil {
+		return e
+	}
+	if e := new(api.EncryptedPair).New(property).Set("TOKEN", "top-secret").Do().Err(); e != nil {
+		return e
+	}
+	return new(api.Description).New(property).Set(description).Do().Err()
+}
+
+func TestRecorder(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "cassette", "flow.json")
+	server := gitapitest.NewServer(vendor.Github)
+	server.Token = "ghp_recorder"
+	property := server.Property("repo")
+
+	// record
+	rec, e := recorder.New(file, recorder.ModeAuto)
+	if e != nil || rec.Mode != recorder.ModeRecord {
+		t.Fatalf("record: %v %v", rec, e)
+	}
+	property.Transport = rec
+	if e = recorderFlow(&property, "desc"); e != nil {
+		t.Fatal(e)
+	}
+	if e = rec.Close(); e != nil {
+		t.Fatal(e)
+	}
+	server.Close()
+	data, _ := os.ReadFile(file)
+	if strings.Contains(string(data), server.Token) || strings.Contains(string(data), "top-secret") {
+		t.Fatalf("cassette not redacted: %s", data)
+	}
+	cassette, e := recorder.Load(file)
+	if e != nil || len(cassette.Interactions) != 5 || cassette.Interactions[3].Request.Body != `{"encrypted_value":"REDACTED","key_id":"568250167242549743"}` {
+		t.Fatalf("cassette: %v %v", cassette, e)
+	}
+
+	// replay, server closed
+	rec, e = recorder.New(file, recorder.ModeAuto)
+	if e != nil || rec.Mode != recorder.ModeReplay {
+		t.Fatalf("replay: %v %v", rec, e)
+	}
+	property.Transport = rec
+	if e = recorderFlow(&property, "desc"); e != nil {
+		t.Fatal(e)
+	}
+	if e = rec.Close(); e != nil {
+		t.Fatal(e)
+	}
+
+	// strict matching
+	rec, _ = recorder.New(file, recorder.ModeReplay)
+	property.Transport = rec
+	if e = recorderFlow(&property, "other"); !errors.Is(e, recorder.ErrMismatch) || !errors.Is(e, base.ErrTransport) {
+		t.Fatalf("mismatch: %v", e)
+	}
+	if e = rec.Close(); !errors.Is(e, recorder.ErrMismatch) {
+		t.Fatalf("not replayed: %v", e)
+	}
+}
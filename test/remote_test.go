@@ -0,0 +1,93 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func writeFile(t *testing.T, file, content string) {
+	if e := os.MkdirAll(filepath.Dir(file), 0o755); e != nil {
+		t.Fatal(e)
+	}
+	if e := os.WriteFile(file, []byte(content), 0o644); e != nil {
+		t.Fatal(e)
+	}
+}
+
+func TestGitRemote(t *testing.T) {
+	root := t.TempDir()
+	// main repository
+	writeFile(t, filepath.Join(root, "main", ".git", "config"), `[core]
+	bare = false
+[remote "origin"]
+	url = git@github.com:J-Siu/go-gitapi.git
+	fetch = +refs/heads/*:refs/remotes/origin/*
+[remote "mirror"]
+	url = https://git.example.com:3000/team/sub/project
+[remote "short"]
+	url = gl:group/tool.git
+[url "ssh://git@gitlab.com/"]
+	insteadOf = gl:
+`)
+	// worktree
+	writeFile(t, filepath.Join(root, "main", ".git", "worktrees", "wt", "commondir"), "../..\n")
+	writeFile(t, filepath.Join(root, "wt", ".git"), "gitdir: ../main/.git/worktrees/wt\n")
+	// submodule
+	writeFile(t, filepath.Join(root, "main", ".git", "modules", "sub", "config"), `[remote "origin"]
+	url = ssh://git@codeberg.org:2222/forge/sub.git
+`)
+	writeFile(t, filepath.Join(root, "main", "sub", ".git"), "gitdir: ../.git/modules/sub\n")
+	os.MkdirAll(filepath.Join(root, "main", "sub", "src"), 0o755)
+
+	var tests = []struct {
+		dir, remote            string
+		vendor                 vendor.Vendor
+		user, repo, entryPoint string
+	}{
+		{"main", "origin", vendor.Github, "J-Siu", "go-gitapi", "https://api.github.com"},
+		{"wt", "origin", vendor.Github, "J-Siu", "go-gitapi", "https://api.github.com"},
+		{"wt", "mirror", vendor.Gitea, "team/sub", "project", "https://git.example.com:3000/api/v1"},
+		{"main", "short", vendor.Gitlab, "group", "tool", "https://gitlab.com/api/v4"},
+		{"main/sub/src", "origin", vendor.Forgejo, "forge", "sub", "https://codeberg.org/api/v1"},
+	}
+	for _, test := range tests {
+		property := base.Property{Vendor: vendor.Gitea}
+		e := base.GitRemote(&property, filepath.Join(root, test.dir), test.remote)
+		if e != nil || property.Vendor != test.vendor || property.User != test.user || property.Repo != test.repo || property.EntryPoint != test.entryPoint {
+			t.Fatalf("%s %s: %#v, %v", test.dir, test.remote, property, e)
+		}
+	}
+
+	property := base.Property{}
+	if e := base.GitRemote(&property, filepath.Join(root, "main"), "none"); e == nil {
+		t.Fatal("missing remote should fail")
+	}
+}
@@ -0,0 +1,127 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/info"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestRepositoryUnmarshal(t *testing.T) {
+	// name full_name owner homepage clone_url html_url fork mirror template private visibility stars pushed_at issues wiki actions
+	for _, test := range []struct {
+		name string
+		body string
+		want string
+	}{
+		{"github", `{"id":1,"name":"repo","full_name":"octo/repo","owner":{"id":9,"login":"octo","type":"User"},
+			"homepage":"https://octo.dev","clone_url":"https://github.com/octo/repo.git","html_url":"https://github.com/octo/repo",
+			"fork":true,"mirror_url":"https://example.com/repo.git","is_template":true,"private":true,"visibility":"private",
+			"stargazers_count":5,"pushed_at":"2025-01-02T03:04:05Z","has_issues":true,"has_wiki":false,
+			"permissions":{"admin":true,"push":true,"pull":true}}`,
+			"repo octo/repo octo https://octo.dev https://github.com/octo/repo.git https://github.com/octo/repo true true true true private 5 2025 true false false"},
+		{"gitea", `{"id":2,"name":"repo","full_name":"user/repo","owner":{"id":1,"login":"user"},
+			"website":"https://user.dev","clone_url":"https://gitea.com/user/repo.git","html_url":"https://gitea.com/user/repo",
+			"fork":false,"mirror":true,"template":true,"private":false,"stars_count":7,
+			"has_issues":true,"has_wiki":true,"has_actions":true,"empty":true}`,
+			"repo user/repo user https://user.dev https://gitea.com/user/repo.git https://gitea.com/user/repo false true true false public 7 1 true true true"},
+		{"gitea internal", `{"id":5,"name":"repo","full_name":"org/repo","owner":{"id":2,"login":"org"},"private":false,"internal":true}`,
+			"repo org/repo org    false false false false internal 0 1 false false false"},
+		{"gitlab", `{"id":3,"name":"repo","path_with_namespace":"group/sub/repo","namespace":{"id":4,"full_path":"group/sub","kind":"group"},
+			"http_url_to_repo":"https://gitlab.com/group/sub/repo.git","web_url":"https://gitlab.com/group/sub/repo",
+			"forked_from_project":{"id":1},"visibility":"private","star_count":3,"last_activity_at":"2024-06-07T08:09:10Z",
+			"issues_enabled":false,"wiki_enabled":true,"jobs_enabled":true}`,
+			"repo group/sub/repo group/sub  https://gitlab.com/group/sub/repo.git https://gitlab.com/group/sub/repo true false false true private 3 2024 false true true"},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var r info.Repository
+			if e := json.Unmarshal([]byte(test.body), &r); e != nil {
+				t.Fatal(e)
+			}
+			// no value is kept from previous decoding
+			var reused info.Repository
+			for _, body := range []string{
+				`{"name":"old","full_name":"o/old","owner":{"login":"o"},"homepage":"https://old.dev","clone_url":"https://old.git","fork":true,"mirror_url":"m","private":true,"topics":["x"]}`,
+				`{"name":"old","path_with_namespace":"g/old","namespace":{"full_path":"g"},"http_url_to_repo":"https://old.git","web_url":"https://old","visibility":"internal"}`,
+				test.body,
+			} {
+				if e := json.Unmarshal([]byte(body), &reused); e != nil {
+					t.Fatal(e)
+				}
+			}
+			if !reflect.DeepEqual(reused, r) {
+				t.Fatalf("reused:\ngot  %+v\nwant %+v", reused, r)
+			}
+			got := fmt.Sprint(r.Name, " ", r.FullName, " ", r.Owner.Login, " ", r.Homepage, " ", r.CloneUrl, " ", r.HtmlUrl, " ",
+				r.Fork, " ", r.Mirror, " ", r.Template, " ", r.Private, " ", r.Visibility, " ", r.Stars, " ", r.PushedAt.Year(), " ",
+				r.HasIssues, " ", r.HasWiki, " ", r.HasActions)
+			if got != test.want {
+				t.Fatalf("\ngot  %s\nwant %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRepositoryGet(t *testing.T) {
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			server.AddRepo("repo", true)
+			server.AddRepo("other", false)
+			property := server.Property("repo")
+
+			repo := new(api.Info).New(&property).Get()
+			if e := repo.Do().Err(); e != nil {
+				t.Fatal(e)
+			}
+			r := repo.Info
+			if r.FullName != "user/repo" || r.Owner.Login != "user" || !r.Private || r.Visibility != "private" ||
+				r.DefaultBranch != "main" || r.CloneUrl != server.URL+"/user/repo.git" || r.CreatedAt.IsZero() ||
+				r.Permissions == nil || !r.Permissions.Admin || !r.HasIssues {
+				t.Fatalf("info: %+v", r)
+			}
+
+			list := new(api.InfoList).New(&property, 1)
+			var names []string
+			for r, e := range list.Iter(t.Context()) {
+				if e != nil {
+					t.Fatal(e)
+				}
+				names = append(names, r.FullName+":"+r.Visibility)
+			}
+			if fmt.Sprint(names) != "[user/other:public user/repo:private]" {
+				t.Fatalf("list: %v", names)
+			}
+		})
+	}
+}
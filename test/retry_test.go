@@ -0,0 +1,133 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+// Fail first n requests with status, 0 to reset connection
+func failServer(n, status int, count *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*count++
+		if *count <= n {
+			if status == 0 {
+				conn, _, _ := w.(http.Hijacker).Hijack()
+				conn.Close()
+				return
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`{"name":"repo","description":"test"}`))
+	}))
+}
+
+func TestRetryPolicy(t *testing.T) {
+	var tests = []struct {
+		name    string
+		status  int
+		create  bool
+		ok      bool
+		attempt int
+	}{
+		{"get 503", http.StatusServiceUnavailable, false, true, 3},
+		{"get reset", 0, false, true, 3},
+		{"get 500", http.StatusInternalServerError, false, false, 1},
+		{"post 503", http.StatusServiceUnavailable, true, false, 1},
+		{"post reset", 0, true, false, 1},
+	}
+	for _, test := range tests {
+		var (
+			count    int
+			server   = failServer(2, test.status, &count)
+			property = base.Property{
+				EntryPoint:  server.URL,
+				RetryPolicy: &base.RetryPolicy{MaxAttempts: 3, Backoff: time.Millisecond},
+			}
+			b *base.Base
+		)
+		if test.create {
+			b = new(api.Repo).New(&property).Create().Do()
+		} else {
+			b = new(api.Description).New(&property).Get().Do()
+		}
+		server.Close()
+		if b.Ok() != test.ok || count != test.attempt {
+			t.Fatalf("%s: ok=%v attempt=%d err=%v", test.name, b.Ok(), count, b.Err())
+		}
+		if test.status == 0 && !test.ok && !errors.Is(b.Err(), base.ErrTransport) {
+			t.Fatalf("%s: unexpected error %v", test.name, b.Err())
+		}
+	}
+}
+
+func TestRetryPolicyDefault(t *testing.T) {
+	backoff := base.RetryBackoffDefault
+	base.RetryBackoffDefault = time.Millisecond
+	defer func() { base.RetryBackoffDefault = backoff }()
+	for _, test := range []struct {
+		name    string
+		policy  *base.RetryPolicy
+		ok      bool
+		attempt int
+	}{
+		{"nil", nil, true, 2},
+		{"disabled", &base.RetryPolicy{MaxAttempts: 1}, false, 1},
+	} {
+		var (
+			count    int
+			server   = failServer(1, http.StatusServiceUnavailable, &count)
+			property = base.Property{EntryPoint: server.URL, RetryPolicy: test.policy}
+			b        = new(api.Description).New(&property).Get().Do()
+		)
+		server.Close()
+		if b.Ok() != test.ok || count != test.attempt {
+			t.Fatalf("%s: ok=%v attempt=%d err=%v", test.name, b.Ok(), count, b.Err())
+		}
+	}
+}
+
+func TestRetryPolicyDelay(t *testing.T) {
+	policy := base.RetryPolicy{Backoff: time.Second, MaxBackoff: 5 * time.Second}
+	for n, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		if d := policy.Delay(n + 1); d != want {
+			t.Fatalf("retry %d: delay %v, want %v", n+1, d, want)
+		}
+	}
+	policy.Jitter = 0.5
+	for range 100 {
+		if d := policy.Delay(1); d < time.Second/2 || d > time.Second*3/2 {
+			t.Fatalf("jitter out of range: %v", d)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/gitapitest"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestRepoRename(t *testing.T) {
+	for _, v := range []vendor.Vendor{vendor.Github, vendor.Gitea} {
+		t.Run(v.String(), func(t *testing.T) {
+			server := gitapitest.NewServer(v)
+			defer server.Close()
+			server.AddRepo("old", false)
+			server.AddRepo("taken", false)
+			property := server.Property("old")
+
+			repo := new(api.Repo).New(&property)
+			if e := repo.Rename("taken").Do().Err(); e == nil || property.Repo != "old" {
+				t.Fatalf("rename to existing: %v %s", e, property.Repo)
+			}
+			if e := repo.Rename("new").Do().Err(); e != nil {
+				t.Fatal(e)
+			}
+			if property.Repo != "new" || server.Repo("user", "new") == nil || server.Repo("user", "old") != nil {
+				t.Fatalf("rename: %s %v", property.Repo, server.Requests())
+			}
+			// Property is used by following requests
+			if e := new(api.Description).New(&property).Set("renamed").Do().Err(); e != nil || server.Repo("user", "new").Description != "renamed" {
+				t.Fatalf("description: %v", e)
+			}
+		})
+	}
+}
+
+func TestRepoTransfer(t *testing.T) {
+	// github, immediate with new name
+	server := gitapitest.NewServer(vendor.Github)
+	defer server.Close()
+	server.AddRepo("svc", false)
+	property := server.Property("svc")
+	repo := new(api.Repo).New(&property)
+	if e := repo.Transfer("org", "app", 1).Do().Err(); !errors.Is(e, base.ErrDestructive) {
+		t.Fatalf("guard: %v", e)
+	}
+	property.AllowDestructive = true
+	if e := repo.Transfer("org", "app", 1).Do().Err(); e != nil {
+		t.Fatal(e)
+	}
+	if property.User != "org" || property.Repo != "app" || server.Repo("org", "app") == nil {
+		t.Fatalf("transfer: %s/%s %v", property.User, property.Repo, server.Requests())
+	}
+	if e := repo.TransferAccept().Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("github accept: %v", e)
+	}
+
+	// gitea, org immediate, user pending
+	gitea := gitapitest.NewServer(vendor.Gitea)
+	defer gitea.Close()
+	gitea.Orgs = []string{"org"}
+	gitea.AddRepo("svc", false)
+	property = gitea.Property("svc")
+	property.AllowDestructive = true
+	repo = new(api.Repo).New(&property)
+	if e := repo.Transfer("org", "app").Do().Err(); !errors.Is(e, base.ErrUnsupported) {
+		t.Fatalf("gitea name: %v", e)
+	}
+	if e := repo.Transfer("org", "").Do().Err(); e != nil || property.User != "org" || gitea.Repo("org", "svc") == nil {
+		t.Fatalf("gitea org: %v %s", e, property.User)
+	}
+	if e := repo.Transfer("other", "").Do().Err(); e != nil || property.User != "org" || gitea.Repo("org", "svc").PendingOwner != "other" {
+		t.Fatalf("gitea pending: %v %s", e, property.User)
+	}
+	if e := repo.TransferReject().Do().Err(); e != nil || property.User != "org" || gitea.Repo("org", "svc").PendingOwner != "" {
+		t.Fatalf("gitea reject: %v %s", e, property.User)
+	}
+	if e := repo.TransferAccept().Do().Err(); e == nil {
+		t.Fatal("accept without pending transfer")
+	}
+	repo.Transfer("other", "").Do()
+	if e := repo.TransferAccept().Do().Err(); e != nil || property.User != "other" || gitea.Repo("other", "svc") == nil {
+		t.Fatalf("gitea accept: %v %s", e, property.User)
+	}
+
+	// gitlab
+	gitlab := base.Property{EntryPoint: server.URL, Vendor: vendor.Gitlab, User: "user", Repo: "svc", DryRun: true}
+	repo = new(api.Repo).New(&gitlab)
+	plan := repo.Transfer("group", "").Do()
+	if plan.Err() != nil || plan.Plan().Steps[0].Method != "PUT" || plan.Plan().Steps[0].Body != `{"namespace":"group"}` || gitlab.User != "user" {
+		t.Fatalf("gitlab: %v\n%s", plan.Err(), *plan.Output())
+	}
+	plan = repo.Rename("app").Do()
+	if plan.Err() != nil || plan.Plan().Steps[0].Body != `{"name":"app","path":"app"}` || gitlab.Repo != "svc" {
+		t.Fatalf("gitlab rename: %v\n%s", plan.Err(), *plan.Output())
+	}
+}
@@ -0,0 +1,139 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/J-Siu/go-gitapi/v4/api"
+	"github.com/J-Siu/go-gitapi/v4/base"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// Write self-signed client certificate and key, return certificate
+func writeClientCert(t *testing.T, certFile, keyFile string) *x509.Certificate {
+	key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "client"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, e := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if e != nil {
+		t.Fatal(e)
+	}
+	keyDer, _ := x509.MarshalECPrivateKey(key)
+	os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600)
+	os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0o600)
+	cert, _ := x509.ParseCertificate(der)
+	return cert
+}
+
+func TestTransport(t *testing.T) {
+	var (
+		dir      = t.TempDir()
+		caFile   = filepath.Join(dir, "ca.pem")
+		certFile = filepath.Join(dir, "cert.pem")
+		keyFile  = filepath.Join(dir, "key.pem")
+		clientCA = x509.NewCertPool()
+	)
+	clientCA.AddCert(writeClientCert(t, certFile, keyFile))
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"description":"` + r.TLS.PeerCertificates[0].Subject.CommonName + `"}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCA}
+	server.Config.ErrorLog = log.New(io.Discard, "", 0) // expected handshake failures
+	server.StartTLS()
+	defer server.Close()
+	os.WriteFile(caFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}), 0o600)
+
+	// ca file, no client cert
+	property := base.Property{EntryPoint: server.URL, Repo: "repo", CaFile: caFile}
+	if e := new(api.Description).New(&property).Get().Do().Err(); e == nil {
+		t.Fatal("client certificate should be required")
+	}
+	// unknown ca
+	property = base.Property{EntryPoint: server.URL, Repo: "repo", CertFile: certFile, KeyFile: keyFile}
+	if e := new(api.Description).New(&property).Get().Do().Err(); e == nil || !strings.Contains(e.Error(), "certificate") {
+		t.Fatalf("unknown ca: %v", e)
+	}
+	// mtls, middleware order
+	var order []string
+	middleware := func(name string) base.Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				order = append(order, name)
+				return next.RoundTrip(r)
+			})
+		}
+	}
+	property.CaFile = caFile
+	property.Middleware = []base.Middleware{middleware("outer"), middleware("inner")}
+	description := new(api.Description).New(&property).Get()
+	if e := description.Do().Err(); e != nil || description.Info.Description != "client" {
+		t.Fatalf("mtls: %v %s", e, description.Info.Description)
+	}
+	if strings.Join(order, ",") != "outer,inner" {
+		t.Fatalf("middleware: %v", order)
+	}
+	// client transport is used, tls options are not applied
+	property = base.Property{EntryPoint: server.URL, Repo: "repo", Client: &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Status: "200 OK", Body: http.NoBody, Header: http.Header{}}, nil
+		}),
+	}}
+	if e := new(api.Description).New(&property).Get().Do().Err(); e != nil {
+		t.Fatalf("client: %v", e)
+	}
+	// bad ca file
+	property = base.Property{EntryPoint: server.URL, Repo: "repo", CaFile: keyFile}
+	if e := new(api.Description).New(&property).Get().Do().Err(); e == nil {
+		t.Fatal("bad ca file should fail")
+	}
+}
@@ -0,0 +1,59 @@
+/*
+The MIT License (MIT)
+
+Copyright © 2025 John, Sing Dao, Siu <john.sd.siu@gmail.com>
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+*/
+
+package gitApi_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/J-Siu/go-gitapi/v4/base"
+	"github.com/J-Siu/go-gitapi/v4/vendor"
+)
+
+func TestVendor(t *testing.T) {
+	for _, v := range vendor.Vendors() {
+		var (
+			property = base.Property{Vendor: v}
+			decoded  base.Property
+		)
+		j, e := json.Marshal(&property)
+		if e != nil {
+			t.Fatal(e)
+		}
+		if e = json.Unmarshal(j, &decoded); e != nil || decoded.Vendor != v {
+			t.Fatalf("%s: %s decoded as %s, %v", v, j, decoded.Vendor, e)
+		}
+	}
+	var property base.Property
+	if e := json.Unmarshal([]byte(`{"vendor":"GitLab"}`), &property); e != nil || property.Vendor != vendor.Gitlab {
+		t.Fatalf("case-insensitive: %s, %v", property.Vendor, e)
+	}
+	if e := json.Unmarshal([]byte(`{"vendor":"svn"}`), &property); e == nil {
+		t.Fatal("unknown vendor should fail")
+	}
+	if j, _ := json.Marshal(vendor.Forgejo); string(j) != `"forgejo"` {
+		t.Fatalf("unexpected json %s", j)
+	}
+}